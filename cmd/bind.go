@@ -29,6 +29,9 @@ func NewCmdBind(streams genericclioptions.IOStreams) *cobra.Command {
 
   # Example: Creating a ScanSettingBinding named "mybinding" that applies the "default-auto-apply" ScanSettings to a tailored CIS Profile.
   %[1]s %[2]s -N mybinding -S default-auto-apply tailoredprofile/ocp4-cis-node-tailored
+
+  # Example: Creating a ScanSettingBinding named "mybinding", along with a ScanSetting named "mybinding" that scans the worker nodes daily at 3:00.
+  %[1]s %[2]s -N mybinding --create-settings --schedule "0 3 * * *" --roles worker profile/ocp4-cis-node
 `
 	)
 
@@ -43,7 +46,16 @@ These objects will take the given ScanSettings and bind them to the given
 Profiles and TailoredProfiles.
 
 If the -S option is not provided, then the ScanSettingBinding will bind the
-"default" ScanSetting (an hourly scan on worker and master nodes).`,
+"default" ScanSetting (an hourly scan on worker and master nodes).
+
+With --create-settings, a new ScanSetting is created along with the binding,
+with the given schedule, roles, raw result storage, remediation and toleration
+options. It's named after the -S option, or after the binding if not provided.
+If the binding can't be created, the ScanSetting is deleted again.
+
+The ScanSetting and the Profiles and TailoredProfiles must exist, and profiles
+that check nodes need a ScanSetting with roles to scan. A warning is printed if
+the ProfileBundle of a profile is not VALID.`,
 		Example:      fmt.Sprintf(usageExamples, "oc compliance", "bind"),
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
@@ -61,9 +73,26 @@ If the -S option is not provided, then the ScanSettingBinding will bind the
 		},
 	}
 
-	cmd.Flags().StringVarP(&o.Settings, "settings", "S", "default", "The scan settings to bind the Profiles/TailoredProfiles to")
+	cmd.Flags().StringVarP(&o.Settings, "settings", "S", "",
+		`The scan settings to bind the Profiles/TailoredProfiles to. Defaults to "default", or to the binding name with --create-settings`)
 	cmd.Flags().StringVarP(&o.Name, "name", "N", "", "The name of the binding to create")
-	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "Output the scansettingbinding that would be created")
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "Output the scansettingbinding (and scansetting) that would be created")
+	cmd.Flags().BoolVar(&o.CreateSettings, "create-settings", false, "Create the scan settings along with the binding")
+	cmd.Flags().StringVar(&o.NewSettings.Schedule, "schedule", "0 1 * * *", "The cron schedule of the scans. Requires --create-settings")
+	cmd.Flags().StringSliceVar(&o.NewSettings.Roles, "roles", []string{"master", "worker"},
+		"The node roles to scan. Requires --create-settings")
+	cmd.Flags().StringVar(&o.NewSettings.RawResultStorageSize, "raw-result-storage-size", "1Gi",
+		"The size of the volume that stores the raw results. Requires --create-settings")
+	cmd.Flags().IntVar(&o.NewSettings.RawResultStorageRotation, "raw-result-storage-rotation", 3,
+		"The number of raw result sets to keep. Requires --create-settings")
+	cmd.Flags().StringVar(&o.NewSettings.RawResultStorageClass, "raw-result-storage-class", "",
+		"The storage class of the volume that stores the raw results. Requires --create-settings")
+	cmd.Flags().BoolVar(&o.NewSettings.AutoApplyRemediations, "auto-apply-remediations", false,
+		"Apply the remediations automatically. Requires --create-settings")
+	cmd.Flags().BoolVar(&o.NewSettings.AutoUpdateRemediations, "auto-update-remediations", false,
+		"Update the remediations automatically when the content changes. Requires --create-settings")
+	cmd.Flags().StringArrayVar(&o.NewSettings.Tolerations, "toleration", nil,
+		"A toleration for the scan pods, as <key>[=<value>][:<effect>]. May be given several times. Requires --create-settings")
 	o.ConfigFlags.AddFlags(cmd.Flags())
 
 	return cmd
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/openshift/oc-compliance/internal/common"
 	"github.com/openshift/oc-compliance/internal/controls"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -19,14 +20,34 @@ func NewCmdControls(streams genericclioptions.IOStreams) *cobra.Command {
 		controlsExamples = `
   # View controls for the "ocp4-cis-node" profile
   %[1]s %[2]s profile ocp4-cis-node
+
+  # Export the NIST-800-53 controls of the "ocp4-moderate" profile as CSV
+  %[1]s %[2]s profile ocp4-moderate -b NIST-800-53 -o csv
+
+  # View controls for the rules enabled in the "my-cis" tailored profile
+  %[1]s %[2]s tailoredprofile my-cis
+
+  # View the status of the NIST-800-53 controls checked by the ScanSettingBinding named "nist-moderate"
+  %[1]s %[2]s scansettingbinding nist-moderate -b NIST-800-53
 `
 	)
 
 	ctx := controls.NewControlsContext(streams)
 	cmd := &cobra.Command{
-		Use:          "controls profile <profile-name>",
-		Short:        "Get a report of what controls you're complying with",
-		Long:         "Get a report of what controls you're complying with",
+		Use:   "controls {profile | tailoredprofile | scansettingbinding | compliancesuite} <object-name>",
+		Short: "Get a report of what controls you're complying with",
+		Long: `Get a report of what controls you're complying with.
+
+For a profile, the report maps each control to the rules that address it. For a
+tailored profile, the rules are the ones of the extended profile plus the
+enabled rules and minus the disabled ones.
+
+For a ScanSettingBinding or ComplianceSuite, the latest result of each rule is
+overlaid on the controls. A rule checked by several scans (e.g. one per node
+role) takes its worst result. A control is PASS if all its rules passed, FAIL
+if all of them failed, PARTIAL if only some failed and MANUAL if none failed
+but some need to be checked manually. The percentage of satisfied controls is
+also reported for each framework.`,
 		Example:      fmt.Sprintf(controlsExamples, "oc compliance", "controls"),
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
@@ -44,8 +65,58 @@ func NewCmdControls(streams genericclioptions.IOStreams) *cobra.Command {
 		},
 	}
 
+	cmd.AddCommand(NewCmdControlsLookup(streams))
+
 	ctx.ConfigFlags.AddFlags(cmd.Flags())
 	cmd.Flags().StringVarP(&ctx.Benchmark, "benchmark", "b", controls.AllBenchmarks,
 		"The benchmark we want to retrieve the controls for")
+	cmd.Flags().StringVarP(&ctx.Output, "output", "o", common.OutputFormatTable,
+		"The output format. One of: table|json|yaml|csv|markdown")
+	return cmd
+}
+
+func NewCmdControlsLookup(streams genericclioptions.IOStreams) *cobra.Command {
+	var (
+		lookupExamples = `
+  # Find what addresses the AC-6 control of NIST 800-53
+  %[1]s %[2]s --benchmark NIST-800-53 --control AC-6
+
+  # Find what addresses the 1.2.1 control of the CIS benchmark, as JSON
+  %[1]s %[2]s -b CIS-OCP -c 1.2.1 -o json
+`
+	)
+
+	ctx := controls.NewLookupContext(streams)
+	cmd := &cobra.Command{
+		Use:   "lookup --benchmark <benchmark> --control <control>",
+		Short: "Find the rules, profiles and results that address a control",
+		Long: `'lookup' finds the rules that address a control of a benchmark, according to
+their control annotations. Enhancements of the control are matched too (e.g.
+AC-6(1) when looking up AC-6).
+
+For each rule, the Profiles and TailoredProfiles that check it and its current
+ComplianceCheckResults are listed.`,
+		Example:      fmt.Sprintf(lookupExamples, "oc compliance", "controls lookup"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := ctx.Complete(c, args); err != nil {
+				return err
+			}
+			if err := ctx.Validate(); err != nil {
+				return err
+			}
+			if err := ctx.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	ctx.ConfigFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringVarP(&ctx.Benchmark, "benchmark", "b", "", "The benchmark the control belongs to (e.g. NIST-800-53)")
+	cmd.Flags().StringVarP(&ctx.Control, "control", "c", "", "The control to look up (e.g. AC-6)")
+	cmd.Flags().StringVarP(&ctx.Output, "output", "o", common.OutputFormatTable,
+		"The output format. One of: table|json|yaml")
 	return cmd
 }
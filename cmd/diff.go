@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+	"github.com/openshift/oc-compliance/internal/diff"
+)
+
+func init() {
+	diffCmd := NewCmdDiff(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	rootCmd.AddCommand(diffCmd)
+}
+
+func NewCmdDiff(streams genericclioptions.IOStreams) *cobra.Command {
+	var (
+		diffExamples = `
+  # Compare the "ocp4-cis" and "ocp4-moderate" profiles
+  %[1]s %[2]s profile ocp4-cis profile ocp4-moderate
+
+  # Compare the "ocp4-cis" profile with a TailoredProfile extending it, as JSON
+  %[1]s %[2]s profile/ocp4-cis tailoredprofile/my-cis -o json
+`
+	)
+
+	ctx := diff.NewDiffContext(streams)
+	cmd := &cobra.Command{
+		Use:   "diff {profile | tailoredprofile} <name> {profile | tailoredprofile} <name>",
+		Short: "Compare what two profiles check",
+		Long: `'diff' compares the effective contents of two Profiles or TailoredProfiles.
+
+It reports the rules and variables that were added or removed, the variables
+whose value changed and the rules whose control annotations changed. Rules are
+matched by their reference in the content, so profiles of different profile
+bundles (e.g. two content versions) can be compared.`,
+		Example:      fmt.Sprintf(diffExamples, "oc compliance", "diff"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := ctx.Complete(c, args); err != nil {
+				return err
+			}
+			if err := ctx.Validate(); err != nil {
+				return err
+			}
+			if err := ctx.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	ctx.ConfigFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringVarP(&ctx.Output, "output", "o", common.OutputFormatTable,
+		"The output format. One of: table|json|yaml")
+	return cmd
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+	"github.com/openshift/oc-compliance/internal/diffresults"
+)
+
+func init() {
+	diffResultsCmd := NewCmdDiffResults(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	rootCmd.AddCommand(diffResultsCmd)
+}
+
+func NewCmdDiffResults(streams genericclioptions.IOStreams) *cobra.Command {
+	var (
+		diffResultsExamples = `
+  # Compare the results of the "cis" and "cis-tailored" ComplianceSuites
+  %[1]s %[2]s compliancesuite cis compliancesuite cis-tailored
+
+  # Compare raw results fetched before and after applying remediations, as JSON
+  %[1]s %[2]s --raw /tmp/before /tmp/after -o json
+`
+	)
+
+	ctx := diffresults.NewDiffResultsContext(streams)
+	cmd := &cobra.Command{
+		Use:   "diff-results {compliancesuite <name> compliancesuite <name> | --raw <directory> <directory>}",
+		Short: "Compare two sets of compliance results",
+		Long: `'diff-results' compares two sets of results and reports the checks that are
+newly failing, newly passing, that disappeared, that appeared, or whose status
+changed otherwise.
+
+The results are either the ComplianceCheckResults of two ComplianceSuites, or
+the raw results found in two directories, such as the ones downloaded with
+'fetch-raw'. Comparing raw results doesn't need access to the cluster, so the
+results of two clusters or of two runs of the same scan may be compared.
+
+The results of ComplianceSuites are matched by rule and by the role of the
+nodes they were checked on (or "platform"). Raw results are matched by rule,
+scanned profile and scan, which is the directory 'fetch-raw' stored them in;
+the directories of suites and result indexes are ignored. The results of the
+nodes of the same scan are aggregated, and become INCONSISTENT if they differ.
+Results that aren't in a scan's directory are matched by their target.`,
+		Example:      fmt.Sprintf(diffResultsExamples, "oc compliance", "diff-results"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := ctx.Complete(c, args); err != nil {
+				return err
+			}
+			if err := ctx.Validate(); err != nil {
+				return err
+			}
+			if err := ctx.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	ctx.ConfigFlags.AddFlags(cmd.Flags())
+	cmd.Flags().BoolVar(&ctx.Raw, "raw", false, "Compare the raw results found in two directories instead of two ComplianceSuites")
+	cmd.Flags().StringVarP(&ctx.Output, "output", "o", common.OutputFormatTable,
+		"The output format. One of: table|json|yaml")
+	return cmd
+}
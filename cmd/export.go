@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/export"
+)
+
+func init() {
+	exportCmd := NewCmdExport(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	rootCmd.AddCommand(exportCmd)
+}
+
+func NewCmdExport(streams genericclioptions.IOStreams) *cobra.Command {
+	var (
+		usageExamples = `
+  # Export the results of the ScanSettingBinding named "mybinding" as SARIF
+  %[1]s %[2]s scansettingbinding mybinding --format sarif
+
+  # Export the results of the ComplianceScan named "ocp4-cis" into a file
+  %[1]s %[2]s compliancescan ocp4-cis --output-file results.sarif
+
+  # Export the raw results fetched into /tmp/results
+  %[1]s %[2]s --raw-dir /tmp/results
+
+  # Export the results of the ComplianceSuite named "mysuite" as JUnit, failing
+  # the checks that need a manual verification
+  %[1]s %[2]s compliancesuite mysuite --format junit --manual-policy failure
+`
+	)
+
+	o := export.NewExportContext(streams)
+
+	cmd := &cobra.Command{
+		Use:   "export {compliancescan | compliancesuite | scansettingbindings} <object-name> | --raw-dir <directory>",
+		Short: "Export compliance results to formats understood by other tools",
+		Long: `'export' converts compliance results into a format that can be ingested by
+other tools, such as security dashboards.
+
+The results are either the ComplianceCheckResults of a ComplianceScan or set of
+ComplianceScans, or the raw (ARF) results found in a directory, such as the ones
+downloaded with 'fetch-raw'. Exporting raw results doesn't need access to the
+cluster, but the rules won't carry the controls they address.
+
+Supported formats:
+  sarif  SARIF 2.1.0 log. Rules are reported with their description and
+         rationale, and the controls they address are listed as taxonomies.
+  junit  JUnit XML with a test suite per scan and a test case per check.
+         Failed checks are failures and the checks that errored are errors.
+         How checks that need manual verification or that have inconsistent
+         results are reported is set with --manual-policy and
+         --inconsistent-policy.`,
+		Example:      fmt.Sprintf(usageExamples, "oc compliance", "export"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	o.ConfigFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&o.Format, "format", export.FormatSARIF, "The format to export the results to. One of: sarif|junit")
+	cmd.Flags().StringVar(&o.RawDir, "raw-dir", "", "Export the raw results found in this directory instead of the results in the cluster")
+	cmd.Flags().StringVar(&o.Policy.Manual, "manual-policy", export.PolicySkipped,
+		"How the JUnit export reports MANUAL checks. One of: skipped|error|failure")
+	cmd.Flags().StringVar(&o.Policy.Inconsistent, "inconsistent-policy", export.PolicyError,
+		"How the JUnit export reports INCONSISTENT checks. One of: skipped|error|failure")
+	cmd.Flags().StringVar(&o.OutputFile, "output-file", "", "Write the exported results to this file instead of the standard output")
+
+	return cmd
+}
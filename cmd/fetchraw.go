@@ -26,6 +26,18 @@ func NewCmdFetchRaw(streams genericclioptions.IOStreams) *cobra.Command {
   
   # Fetch from scansettingbinding named "mybinding" into /tmp
   %[1]s %[2]s scansettingbindings mybinding -o /tmp
+
+  # Fetch all the result sets kept for the compliancescan named "myscan" into /tmp
+  %[1]s %[2]s compliancescan myscan --all-indexes -o /tmp
+
+  # Fetch the result sets written in the last two days for the compliancesuite named "mysuite" into /tmp
+  %[1]s %[2]s compliancesuite mysuite --since 48h -o /tmp
+
+  # Fetch the results of four scans at a time from the compliancesuite named "mysuite" into /tmp, even if some fail
+  %[1]s %[2]s compliancesuite mysuite --parallel 4 --continue-on-error -o /tmp
+
+  # Fetch from compliancescan named "myscan" into /tmp without creating an extractor pod
+  %[1]s %[2]s compliancescan myscan --transport resultserver -o /tmp
 `
 	)
 
@@ -37,7 +49,24 @@ func NewCmdFetchRaw(streams genericclioptions.IOStreams) *cobra.Command {
 		Long: `'fetch-raw' fetches the raw results for a scan or set of scans.
 
 This command allows you to download archives of the raw (ARF) results from a
-ComplianceScan, ComplianceSuite, or ScanSettingBinding to a specified directory.`,
+ComplianceScan, ComplianceSuite, or ScanSettingBinding to a specified directory.
+
+By default, the results of the latest scan are fetched. The operator keeps
+several rotated result sets, each one identified by an index. Older result sets
+may be fetched with the --index, --all-indexes and --since flags, in which case
+each one is persisted in a directory named after its index.
+
+The scans of a suite are fetched one at a time, and fetching stops at the first
+scan that fails. --parallel fetches several scans at once, and with
+--continue-on-error the rest of the scans are fetched even if one fails.
+
+The results are fetched from a pod that mounts the results volume. By default,
+a running pod that already mounts it (e.g. the operator's resultserver) is
+used, and if there's none, or fetching from it fails, an extractor pod is
+created with the image given by --image. --transport selects a single way of
+fetching the results instead: 'resultserver' only uses a running pod, 'exec'
+only uses an extractor pod and streams the results through tar, and 'cp' only
+uses an extractor pod and copies the results like 'oc cp' does.`,
 		Example:      fmt.Sprintf(usageExamples, "oc compliance", "fetch-raw"),
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
@@ -57,8 +86,19 @@ ComplianceScan, ComplianceSuite, or ScanSettingBinding to a specified directory.
 
 	cmd.Flags().StringVarP(&o.OutputPath, "output", "o", ".", "The path where you want to persist the raw results to")
 	cmd.Flags().StringVarP(&o.Image, "image", "i", "registry.access.redhat.com/ubi8/ubi:latest",
-		"The container image to use to fetch the raw results from the compliance scan. Must contain the cp and tar commands.")
-	cmd.Flags().BoolVar(&o.HTML, "html", false, "Whether to render the raw results to HTML (Requires the 'oscap' command)")
+		"The container image to use to fetch the raw results from the compliance scan. Must contain the cp, tar, ls and stat commands.")
+	cmd.Flags().StringVar(&o.Transport, "transport", fetchraw.TransportAuto,
+		"How to fetch the raw results. One of: auto|resultserver|exec|cp. 'auto' uses a running pod that mounts the results volume if there's one, and falls back to an extractor pod")
+	cmd.Flags().BoolVar(&o.HTML, "html", false, "Whether to render the raw results to HTML")
+	cmd.Flags().StringVar(&o.HTMLRenderer, "html-renderer", fetchraw.NativeRenderer,
+		"How to render the HTML reports. One of: native|oscap. The 'oscap' renderer requires the 'oscap' command")
+	cmd.Flags().Int64Var(&o.Index, "index", fetchraw.CurrentIndex, "The index of the result set to fetch. Defaults to the scan's current index")
+	cmd.Flags().BoolVar(&o.AllIndexes, "all-indexes", false, "Fetch all the result sets available in the results volume")
+	cmd.Flags().StringVar(&o.Since, "since", "",
+		"Fetch the result sets written after the given time. Either an RFC3339 timestamp or a duration relative to now (e.g. 48h)")
+	cmd.Flags().IntVar(&o.Parallel.Parallelism, "parallel", 1, "The number of scans of a suite to fetch at once")
+	cmd.Flags().BoolVar(&o.Parallel.ContinueOnError, "continue-on-error", false,
+		"Keep fetching the rest of the scans when one of them fails. The failures are reported at the end")
 	o.ConfigFlags.AddFlags(cmd.Flags())
 
 	return cmd
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+	"github.com/openshift/oc-compliance/internal/inspectraw"
+)
+
+func init() {
+	inspectRawCmd := NewCmdInspectRaw(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	rootCmd.AddCommand(inspectRawCmd)
+}
+
+func NewCmdInspectRaw(streams genericclioptions.IOStreams) *cobra.Command {
+	var (
+		usageExamples = `
+  # List all the results fetched into /tmp/results
+  %[1]s %[2]s /tmp/results
+
+  # List the failed high severity results fetched into /tmp/results
+  %[1]s %[2]s /tmp/results --result fail --severity high
+
+  # List the results of the rules related to the API server as JSON
+  %[1]s %[2]s /tmp/results --rule api_server -o json
+`
+	)
+
+	o := inspectraw.NewInspectRawContext(streams)
+
+	cmd := &cobra.Command{
+		Use:   "inspect-raw <directory> [--result <result>] [--severity <severity>] [--rule <rule>] [--target <target>]",
+		Short: "List the results in fetched raw compliance results",
+		Long: `'inspect-raw' lists and filters the rule results found in raw (ARF) results.
+
+The results are read from the files in the given directory, such as the ones
+downloaded with 'fetch-raw'. No access to the cluster is needed.`,
+		Example:      fmt.Sprintf(usageExamples, "oc compliance", "inspect-raw"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Output, "output", "o", common.OutputFormatTable,
+		"The output format. One of: table|json|yaml")
+	cmd.Flags().StringSliceVar(&o.Results, "result", nil,
+		"Only list results with the given result (e.g. pass, fail, notapplicable)")
+	cmd.Flags().StringSliceVar(&o.Severities, "severity", nil,
+		"Only list results with the given severity (e.g. high, medium, low)")
+	cmd.Flags().StringVar(&o.Filter.Rule, "rule", "", "Only list results of rules whose ID contains the given text")
+	cmd.Flags().StringVar(&o.Filter.Target, "target", "", "Only list results of targets whose name contains the given text")
+	cmd.Flags().StringVar(&o.Filter.Source, "source", "",
+		"Only list results found in directories whose path contains the given text (e.g. a scan name)")
+
+	return cmd
+}
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+
+	"github.com/openshift/oc-compliance/internal/common"
 )
 
 var rootCmd = &cobra.Command{
@@ -23,6 +26,10 @@ func main() {
 	pflag.CommandLine = flags
 
 	if err := rootCmd.Execute(); err != nil {
+		var exitErr *common.ExitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/openshift/oc-compliance/internal/common"
 	"github.com/openshift/oc-compliance/internal/rerunnow"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -25,14 +26,25 @@ func NewCmdRerunNow(streams genericclioptions.IOStreams) *cobra.Command {
   
   # Re-run all ComplianceSuites bound by the ScanSettingBinding named "mybinding"
   %[1]s %[2]s scansettingbindings mybinding
+
+  # Re-run all scans in a ComplianceSuite named "mysuite", and wait up to an hour for them to finish
+  %[1]s %[2]s compliancesuite mysuite --wait --timeout 1h
 `
 	)
 
 	ctx := rerunnow.NewReRunNowContext(streams)
 	cmd := &cobra.Command{
-		Use:          "rerun-now {compliancescan | compliancesuite | scansettingbindings} <object-name>",
-		Short:        "Force a re-scan for one or more ComplianceScans",
-		Long:         `'rerun-now' forces a ComplianceScan or set of ComplianceScans to be retriggered.`,
+		Use:   "rerun-now {compliancescan | compliancesuite | scansettingbindings} <object-name>",
+		Short: "Force a re-scan for one or more ComplianceScans",
+		Long: `'rerun-now' forces a ComplianceScan or set of ComplianceScans to be retriggered.
+
+With --wait, the command waits for the scans to finish, printing the phases
+they go through, and exits according to their results: 0 if all of them are
+COMPLIANT, 2 if any is NON-COMPLIANT and 3 if any finished with an ERROR or
+INCONSISTENT result. This allows CI jobs to trigger scans and block on them.
+
+--parallel re-runs several scans of a suite at once, and with
+--continue-on-error the rest of the scans are re-run even if one fails.`,
 		Example:      fmt.Sprintf(rerunExamples, "oc compliance", "rerun-now"),
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
@@ -51,5 +63,10 @@ func NewCmdRerunNow(streams genericclioptions.IOStreams) *cobra.Command {
 	}
 
 	ctx.ConfigFlags.AddFlags(cmd.Flags())
+	cmd.Flags().BoolVar(&ctx.Wait, "wait", false, "Wait for the scans to finish, and exit according to their results")
+	cmd.Flags().DurationVar(&ctx.Timeout, "timeout", common.Timeout, "How long to wait for the scans to finish")
+	cmd.Flags().IntVar(&ctx.Parallel.Parallelism, "parallel", 1, "The number of scans of a suite to re-run at once")
+	cmd.Flags().BoolVar(&ctx.Parallel.ContinueOnError, "continue-on-error", false,
+		"Keep re-running the rest of the scans when one of them fails. The failures are reported at the end")
 	return cmd
 }
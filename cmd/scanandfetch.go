@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+	"github.com/openshift/oc-compliance/internal/fetchraw"
+	"github.com/openshift/oc-compliance/internal/scanandfetch"
+)
+
+func init() {
+	scanAndFetchCmd := NewCmdScanAndFetch(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	rootCmd.AddCommand(scanAndFetchCmd)
+}
+
+func NewCmdScanAndFetch(streams genericclioptions.IOStreams) *cobra.Command {
+	var (
+		usageExamples = `
+  # Re-run the scans of the scansettingbinding named "mybinding" and fetch their results into a directory in /tmp
+  %[1]s %[2]s mybinding -o /tmp
+
+  # Same as above, rendering HTML reports of the results too
+  %[1]s %[2]s mybinding -o /tmp --html
+`
+	)
+
+	o := scanandfetch.NewScanAndFetchContext(streams)
+
+	cmd := &cobra.Command{
+		Use:   "scan-and-fetch <scansettingbinding name> -o <output path>",
+		Short: "Re-run the scans of a ScanSettingBinding and download their raw results",
+		Long: `'scan-and-fetch' re-runs the scans of a ScanSettingBinding, waits for them
+to finish and fetches their raw results, as 'rerun-now --wait' followed by
+'fetch-raw' would.
+
+The results are stored in a directory named "<binding>-<timestamp>" inside the
+output path, so subsequent runs don't overwrite each other.
+
+The exit code reflects the results of the scans like with 'rerun-now --wait':
+2 if any scan is NON-COMPLIANT and 3 if any finished with an ERROR or
+INCONSISTENT result. The results are fetched in either case.`,
+		Example:      fmt.Sprintf(usageExamples, "oc compliance", "scan-and-fetch"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.OutputPath, "output", "o", ".", "The path where the directory with the raw results is created")
+	cmd.Flags().StringVarP(&o.Image, "image", "i", "registry.access.redhat.com/ubi8/ubi:latest",
+		"The container image to use to fetch the raw results from the compliance scan. Must contain the cp, tar, ls and stat commands.")
+	cmd.Flags().StringVar(&o.Transport, "transport", fetchraw.TransportAuto,
+		"How to fetch the raw results. One of: auto|resultserver|exec|cp. 'auto' uses a running pod that mounts the results volume if there's one, and falls back to an extractor pod")
+	cmd.Flags().BoolVar(&o.HTML, "html", false, "Whether to render the raw results to HTML")
+	cmd.Flags().StringVar(&o.HTMLRenderer, "html-renderer", fetchraw.NativeRenderer,
+		"How to render the HTML reports. One of: native|oscap. The 'oscap' renderer requires the 'oscap' command")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", common.Timeout, "How long to wait for the scans to finish")
+	cmd.Flags().IntVar(&o.Parallel.Parallelism, "parallel", 1, "The number of scans of a suite to re-run and fetch at once")
+	cmd.Flags().BoolVar(&o.Parallel.ContinueOnError, "continue-on-error", false,
+		"Keep re-running and fetching the rest of the scans when one of them fails. The failures are reported at the end")
+	o.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+	"github.com/openshift/oc-compliance/internal/summary"
+)
+
+func init() {
+	summaryCmd := NewCmdSummary(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	rootCmd.AddCommand(summaryCmd)
+}
+
+func NewCmdSummary(streams genericclioptions.IOStreams) *cobra.Command {
+	var (
+		summaryExamples = `
+  # Summarize the results of all the scans bound by the ScanSettingBinding named "mybinding"
+  %[1]s %[2]s scansettingbinding mybinding
+
+  # Summarize the results of the ComplianceSuite named "mysuite" as JSON
+  %[1]s %[2]s compliancesuite mysuite -o json
+
+  # Fail if more than 10 checks of the ComplianceScan named "ocp4-cis" failed
+  %[1]s %[2]s compliancescan ocp4-cis --fail-threshold 10
+`
+	)
+
+	ctx := summary.NewSummaryContext(streams)
+	cmd := &cobra.Command{
+		Use:   "summary {compliancescan | compliancesuite | scansettingbindings} <object-name>",
+		Short: "Summarize the results of one or more ComplianceScans",
+		Long: `'summary' counts the ComplianceCheckResults of a ComplianceScan or set of
+ComplianceScans by status and severity.
+
+The default table output has two tables: the results of each scan by status,
+and the results of all the scans by severity and status. With '-o json' or
+'-o yaml', the same counts are printed as a single document instead, which also
+breaks down the results of each scan by severity.
+
+If --fail-threshold is set, the command exits with an error when the number of
+failed checks is higher than the threshold.`,
+		Example:      fmt.Sprintf(summaryExamples, "oc compliance", "summary"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := ctx.Complete(c, args); err != nil {
+				return err
+			}
+			if err := ctx.Validate(); err != nil {
+				return err
+			}
+			if err := ctx.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	ctx.ConfigFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringVarP(&ctx.Output, "output", "o", common.OutputFormatTable,
+		"The output format. One of: table|json|yaml")
+	cmd.Flags().IntVar(&ctx.FailThreshold, "fail-threshold", summary.NoFailThreshold,
+		"Exit with an error if more checks than this failed. A negative value disables the check")
+	return cmd
+}
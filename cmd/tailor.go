@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/tailor"
+)
+
+func init() {
+	tailorCmd := NewCmdTailor(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	rootCmd.AddCommand(tailorCmd)
+}
+
+func NewCmdTailor(streams genericclioptions.IOStreams) *cobra.Command {
+	var (
+		usageExamples = `
+  # Create a TailoredProfile
+  %[1]s %[2]s <tailoredprofile name> --extends profile/<profile name> [--disable-rule <rule>] [--enable-rule <rule>] [--set-var <variable>=<value>] [--rationale <rationale>]
+
+  # Display a TailoredProfile
+  %[1]s %[2]s --dry-run <tailoredprofile name> --extends profile/<profile name> [--disable-rule <rule>] [--enable-rule <rule>] [--set-var <variable>=<value>]
+
+  # Example: Creating a TailoredProfile named "my-cis" that extends the CIS profile, without checking anonymous auth
+  %[1]s %[2]s my-cis --extends profile/ocp4-cis --disable-rule ocp4-api-server-anonymous-auth --rationale "Needed by our health checks"
+
+  # Example: Creating a TailoredProfile named "my-moderate" that extends the moderate profile, logging request bodies in the API server audit log
+  %[1]s %[2]s my-moderate --extends profile/ocp4-moderate --set-var ocp4-var-openshift-audit-profile=WriteRequestBodies
+`
+	)
+
+	o := tailor.NewTailorContext(streams)
+
+	cmd := &cobra.Command{
+		Use:   "tailor [--dry-run] <tailoredprofile name> --extends profile/<profile name> [--disable-rule <rule>] [--enable-rule <rule>] [--set-var <variable>=<value>]",
+		Short: "Creates a TailoredProfile for the given parameters",
+		Long: `'tailor' will take the given parameters and create a TailoredProfile object.
+
+The TailoredProfile extends the given Profile, enabling or disabling the given
+rules and setting the given variables. The rules and variables must exist, and
+come from the same ProfileBundle as the extended Profile.
+
+The rationale is recorded for each of the rules and variables. If no title or
+description are provided, they're derived from the extended Profile.
+
+The resulting TailoredProfile can then be scanned with 'bind'.`,
+		Example:      fmt.Sprintf(usageExamples, "oc compliance", "tailor"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := o.Complete(c, args); err != nil {
+				return err
+			}
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Extends, "extends", "e", "", "The Profile to extend")
+	cmd.Flags().StringArrayVar(&o.EnableRules, "enable-rule", nil, "A rule to enable. May be given several times")
+	cmd.Flags().StringArrayVar(&o.DisableRules, "disable-rule", nil, "A rule to disable. May be given several times")
+	cmd.Flags().StringArrayVar(&o.SetVars, "set-var", nil, "A variable to set, as <variable>=<value>. May be given several times")
+	cmd.Flags().StringVarP(&o.Rationale, "rationale", "r", "", "The rationale for the enabled and disabled rules and the set variables")
+	cmd.Flags().StringVar(&o.Title, "title", "", "The title of the TailoredProfile")
+	cmd.Flags().StringVar(&o.Description, "description", "", "The description of the TailoredProfile")
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "Output the tailoredprofile that would be created")
+	o.ConfigFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
+	"github.com/openshift/oc-compliance/internal/common"
 	"github.com/openshift/oc-compliance/internal/viewresult"
 )
 
@@ -20,14 +21,23 @@ func NewCmdViewResult(streams genericclioptions.IOStreams) *cobra.Command {
 		rerunExamples = `
   # Viewing the ComplianceCheckResult named "ocp4-cis-scheduler-no-bind-address"
   %[1]s %[2]s ocp4-cis-scheduler-no-bind-address
+
+  # Viewing the ComplianceCheckResult named "ocp4-cis-scheduler-no-bind-address" as JSON
+  %[1]s %[2]s -o json ocp4-cis-scheduler-no-bind-address
+
+  # Viewing all the failing ComplianceCheckResults of the "mysuite" ComplianceSuite
+  %[1]s %[2]s -l compliance.openshift.io/check-status=FAIL,compliance.openshift.io/suite=mysuite
 `
 	)
 
 	ctx := viewresult.NewViewResultContext(streams)
 	cmd := &cobra.Command{
-		Use:          "view-result <result-name>",
-		Short:        "View a ComplianceCheckResult",
-		Long:         `'view-result' exposes more information about a ComplianceCheckResult.`,
+		Use:   "view-result [-o table|json|yaml] {<result-name> [..<result-name>] | -l <selector>}",
+		Short: "View one or more ComplianceCheckResults",
+		Long: `'view-result' exposes more information about a ComplianceCheckResult.
+
+Several results may be viewed at once by passing several names and/or a label
+selector.`,
 		Example:      fmt.Sprintf(rerunExamples, "oc compliance", "view-result"),
 		SilenceUsage: true,
 		RunE: func(c *cobra.Command, args []string) error {
@@ -46,5 +56,9 @@ func NewCmdViewResult(streams genericclioptions.IOStreams) *cobra.Command {
 	}
 
 	ctx.ConfigFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringVarP(&ctx.Output, "output", "o", common.OutputFormatTable,
+		"The output format. One of: table|json|yaml")
+	cmd.Flags().StringVarP(&ctx.Selector, "selector", "l", "",
+		"Label selector to filter the ComplianceCheckResults to view")
 	return cmd
 }
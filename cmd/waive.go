@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/waive"
+)
+
+func init() {
+	waiveCmd := NewCmdWaive(genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr})
+	rootCmd.AddCommand(waiveCmd)
+}
+
+func NewCmdWaive(streams genericclioptions.IOStreams) *cobra.Command {
+	var (
+		waiveExamples = `
+  # Display the TailoredProfiles that waive the failing and manual checks of the ComplianceSuite named "mysuite"
+  %[1]s %[2]s --dry-run compliancesuite mysuite
+
+  # Create TailoredProfiles that waive the low severity failing checks of the ComplianceSuite named "mysuite"
+  %[1]s %[2]s compliancesuite mysuite --status FAIL --severity low --rationale "Accepted by the security team"
+
+  # Create TailoredProfiles that only waive the checks of the ComplianceSuite named "mysuite" that ran on worker nodes
+  %[1]s %[2]s compliancesuite mysuite -l compliance.openshift.io/scan-name=ocp4-cis-node-worker
+`
+	)
+
+	ctx := waive.NewWaiveContext(streams)
+	cmd := &cobra.Command{
+		Use:   "waive [--dry-run] compliancesuite <object-name>",
+		Short: "Creates TailoredProfiles that waive the checks a ComplianceSuite didn't pass",
+		Long: `'waive' creates TailoredProfiles that accept the current exceptions of a
+ComplianceSuite.
+
+The ComplianceCheckResults of the suite are filtered by status (FAIL and MANUAL
+by default), severity and labels. For each profile that the suite scanned, a
+TailoredProfile named "<profile>-<suffix>" is created, which checks the same as
+the profile except for the rules of those results. The rationale of each
+disabled rule references the results it waives, so the exceptions can be kept
+as code and traced back.
+
+The TailoredProfiles may then be scanned instead of the original profiles with
+'bind'.`,
+		Example:      fmt.Sprintf(waiveExamples, "oc compliance", "waive"),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := ctx.Complete(c, args); err != nil {
+				return err
+			}
+			if err := ctx.Validate(); err != nil {
+				return err
+			}
+			if err := ctx.Run(); err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	ctx.ConfigFlags.AddFlags(cmd.Flags())
+	cmd.Flags().StringSliceVar(&ctx.Statuses, "status", []string{"FAIL", "MANUAL"},
+		"The statuses of the checks to waive. Any of: FAIL|MANUAL|INCONSISTENT|ERROR|INFO")
+	cmd.Flags().StringSliceVar(&ctx.Severities, "severity", nil,
+		"The severities of the checks to waive. Any of: high|medium|low|unknown. All of them by default")
+	cmd.Flags().StringVarP(&ctx.Selector, "selector", "l", "",
+		"A label selector to filter the checks to waive")
+	cmd.Flags().StringVarP(&ctx.Rationale, "rationale", "r", "",
+		"The rationale for the waivers. The waived results are appended to it")
+	cmd.Flags().StringVar(&ctx.Suffix, "suffix", "waived",
+		"The suffix appended to the profile names to name the TailoredProfiles")
+	cmd.Flags().BoolVar(&ctx.DryRun, "dry-run", false, "Output the tailoredprofiles that would be created")
+	return cmd
+}
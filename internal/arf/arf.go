@@ -0,0 +1,353 @@
+// Package arf decodes the raw results that the compliance-operator stores for
+// every scan: ARF (Asset Reporting Format) documents containing the XCCDF
+// benchmark that was evaluated and the resulting XCCDF TestResult.
+package arf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const xccdfNamespace = "http://checklists.nist.gov/xccdf/1.2"
+
+// subMarker delimits the references to values that are kept in a Text
+// until the values are known. A reference is the 'use' attribute of the
+// <sub> element and the ID of the value, separated by a colon, which IDs
+// can't contain.
+const subMarker = "\x00"
+
+// bzip2Magic is the header of bzip2 compressed data
+var bzip2Magic = []byte("BZh")
+
+// Report holds the relevant contents of an ARF document
+type Report struct {
+	Benchmark  Benchmark
+	TestResult TestResult
+}
+
+// Benchmark holds the XCCDF benchmark that was evaluated
+type Benchmark struct {
+	ID    string
+	Title string
+	// Rules of the benchmark indexed by ID
+	Rules map[string]*Rule
+	// Values of the benchmark indexed by ID
+	Values map[string]*Value
+}
+
+// Rule is an XCCDF rule definition
+type Rule struct {
+	ID          string  `xml:"id,attr"`
+	Severity    string  `xml:"severity,attr"`
+	Title       Text    `xml:"title"`
+	Description Text    `xml:"description"`
+	Rationale   Text    `xml:"rationale"`
+	Idents      []Ident `xml:"ident"`
+	Checks      []Check `xml:"check"`
+}
+
+// Value is an XCCDF value that tunes the rules, e.g. a timeout. Its texts
+// may be substituted into the texts of the rules.
+type Value struct {
+	ID      string        `xml:"id,attr"`
+	Title   Text          `xml:"title"`
+	Choices []ValueChoice `xml:"value"`
+}
+
+// ValueChoice is one of the possible values of a Value. The one without a
+// selector is the default.
+type ValueChoice struct {
+	Selector string `xml:"selector,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// DefaultValue gets the value used when no profile sets another one
+func (v *Value) DefaultValue() string {
+	for _, choice := range v.Choices {
+		if choice.Selector == "" {
+			return strings.TrimSpace(choice.Value)
+		}
+	}
+	return ""
+}
+
+// TestResult is the XCCDF result of evaluating a profile on a target
+type TestResult struct {
+	ID          string       `xml:"id,attr"`
+	StartTime   string       `xml:"start-time,attr"`
+	EndTime     string       `xml:"end-time,attr"`
+	Title       string       `xml:"title"`
+	Profile     IDRef        `xml:"profile"`
+	Target      string       `xml:"target"`
+	TargetFacts []Fact       `xml:"target-facts>fact"`
+	SetValues   []SetValue   `xml:"set-value"`
+	RuleResults []RuleResult `xml:"rule-result"`
+	Scores      []Score      `xml:"score"`
+}
+
+// SetValue is the value that the evaluated profile gave to a Value
+type SetValue struct {
+	IDRef string `xml:"idref,attr"`
+	Value string `xml:",chardata"`
+}
+
+// RuleResult is the result of evaluating a rule
+type RuleResult struct {
+	IDRef    string  `xml:"idref,attr"`
+	Severity string  `xml:"severity,attr"`
+	Time     string  `xml:"time,attr"`
+	Result   string  `xml:"result"`
+	Idents   []Ident `xml:"ident"`
+	Checks   []Check `xml:"check"`
+}
+
+// IDRef is a reference to another XCCDF item
+type IDRef struct {
+	IDRef string `xml:"idref,attr"`
+}
+
+// Ident is an identifier of a rule in an external system (e.g. a CCE)
+type Ident struct {
+	System string `xml:"system,attr"`
+	Value  string `xml:",chardata"`
+}
+
+// Check is a reference to the check that evaluates a rule
+type Check struct {
+	System      string            `xml:"system,attr"`
+	ContentRefs []CheckContentRef `xml:"check-content-ref"`
+}
+
+// CheckContentRef points to the check definition (e.g. an OVAL definition)
+type CheckContentRef struct {
+	Name string `xml:"name,attr"`
+	Href string `xml:"href,attr"`
+}
+
+// Fact is a fact gathered about the scanned target
+type Fact struct {
+	Name  string `xml:"name,attr"`
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Score is the score of the TestResult for a scoring system
+type Score struct {
+	System  string `xml:"system,attr"`
+	Maximum string `xml:"maximum,attr"`
+	Value   string `xml:",chardata"`
+}
+
+// Text is the text content of an element, without any markup it might
+// contain (e.g. the XHTML used in rule descriptions). The values referenced
+// with <sub> are substituted once the whole document is decoded.
+type Text string
+
+func (t *Text) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var b strings.Builder
+	depth := 0
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tt := tok.(type) {
+		case xml.CharData:
+			b.Write(tt)
+		case xml.StartElement:
+			if tt.Name.Space == xccdfNamespace && tt.Name.Local == "sub" {
+				b.WriteString(subMarker + getAttr(tt, "use") + ":" + getAttr(tt, "idref") + subMarker)
+			}
+			depth++
+		case xml.EndElement:
+			if depth == 0 {
+				*t = Text(strings.TrimSpace(b.String()))
+				return nil
+			}
+			depth--
+		}
+	}
+}
+
+func (t Text) String() string {
+	return string(t)
+}
+
+// resolve substitutes the values referenced in the text. The value that the
+// evaluated profile set is used if any, or the default otherwise. References
+// that can't be resolved are left as the ID of the value, so they're still
+// noticeable.
+func (t Text) resolve(values map[string]*Value, setValues map[string]string) Text {
+	if !strings.Contains(string(t), subMarker) {
+		return t
+	}
+	var b strings.Builder
+	for idx, part := range strings.Split(string(t), subMarker) {
+		// The text and the references alternate, starting with text
+		if idx%2 == 0 {
+			b.WriteString(part)
+			continue
+		}
+		use, idref := "", part
+		if sep := strings.Index(part, ":"); sep >= 0 {
+			use, idref = part[:sep], part[sep+1:]
+		}
+		useTitle := use == "title"
+
+		value, found := values[idref]
+		switch {
+		case useTitle && found && value.Title != "":
+			b.WriteString(value.Title.String())
+		case useTitle:
+			b.WriteString(idref)
+		case setValues[idref] != "":
+			b.WriteString(setValues[idref])
+		case found && value.DefaultValue() != "":
+			b.WriteString(value.DefaultValue())
+		default:
+			b.WriteString(idref)
+		}
+	}
+	return Text(b.String())
+}
+
+// ParseFile parses the ARF document in the given path. The file may be
+// bzip2 compressed, as the operator does with big results.
+func ParseFile(path string) (*Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	report, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse ARF file %s: %s", path, err)
+	}
+	return report, nil
+}
+
+// Parse parses an ARF document, decompressing it if needed
+func Parse(r io.Reader) (*Report, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(len(bzip2Magic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	var in io.Reader = br
+	if bytes.Equal(header, bzip2Magic) {
+		in = bzip2.NewReader(br)
+	}
+	return decode(in)
+}
+
+// decode goes through the document and only decodes the elements it cares
+// about. This avoids holding the parts of the data stream that aren't needed
+// (e.g. OVAL definitions) in memory.
+func decode(r io.Reader) (*Report, error) {
+	report := &Report{
+		Benchmark: Benchmark{
+			Rules:  map[string]*Rule{},
+			Values: map[string]*Value{},
+		},
+	}
+	foundResult := false
+	inBenchmark := false
+	depth := 0
+	benchmarkDepth := 0
+
+	d := xml.NewDecoder(r)
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch tt := tok.(type) {
+		case xml.StartElement:
+			if tt.Name.Space != xccdfNamespace {
+				depth++
+				continue
+			}
+			switch tt.Name.Local {
+			case "Benchmark":
+				inBenchmark = true
+				benchmarkDepth = depth
+				report.Benchmark.ID = getAttr(tt, "id")
+				depth++
+			case "title":
+				var title Text
+				if err := d.DecodeElement(&title, &tt); err != nil {
+					return nil, err
+				}
+				// Only the benchmark's own title is of interest
+				if inBenchmark && depth == benchmarkDepth+1 {
+					report.Benchmark.Title = title.String()
+				}
+			case "Rule":
+				rule := &Rule{}
+				if err := d.DecodeElement(rule, &tt); err != nil {
+					return nil, err
+				}
+				report.Benchmark.Rules[rule.ID] = rule
+			case "Value":
+				value := &Value{}
+				if err := d.DecodeElement(value, &tt); err != nil {
+					return nil, err
+				}
+				report.Benchmark.Values[value.ID] = value
+			case "TestResult":
+				if err := d.DecodeElement(&report.TestResult, &tt); err != nil {
+					return nil, err
+				}
+				foundResult = true
+			default:
+				depth++
+			}
+		case xml.EndElement:
+			depth--
+			if inBenchmark && depth == benchmarkDepth {
+				inBenchmark = false
+			}
+		}
+	}
+
+	if !foundResult {
+		return nil, fmt.Errorf("the document contains no XCCDF TestResult")
+	}
+	report.resolveSubs()
+	return report, nil
+}
+
+// resolveSubs substitutes the values referenced in the texts of the rules,
+// which can only be done once the values and the TestResult are decoded
+func (r *Report) resolveSubs() {
+	setValues := map[string]string{}
+	for _, sv := range r.TestResult.SetValues {
+		setValues[sv.IDRef] = strings.TrimSpace(sv.Value)
+	}
+	for _, rule := range r.Benchmark.Rules {
+		rule.Title = rule.Title.resolve(r.Benchmark.Values, setValues)
+		rule.Description = rule.Description.resolve(r.Benchmark.Values, setValues)
+		rule.Rationale = rule.Rationale.resolve(r.Benchmark.Values, setValues)
+	}
+}
+
+func getAttr(elem xml.StartElement, name string) string {
+	for _, attr := range elem.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,119 @@
+package arf
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	timeoutRuleID = "xccdf_org.ssgproject.content_rule_api_server_request_timeout"
+	auditRuleID   = "xccdf_org.ssgproject.content_rule_audit_profile_set"
+)
+
+func TestParseFile(t *testing.T) {
+	for _, path := range []string{"testdata/report.xml", "testdata/report.xml.bzip2"} {
+		t.Run(path, func(t *testing.T) {
+			report, err := ParseFile(path)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			if report.Benchmark.ID != "xccdf_org.ssgproject.content_benchmark_OCP-4" {
+				t.Errorf("Unexpected benchmark ID '%s'", report.Benchmark.ID)
+			}
+			expectedTitle := "Guide to the Secure Configuration of Red Hat OpenShift Container Platform 4"
+			if report.Benchmark.Title != expectedTitle {
+				t.Errorf("Expected the benchmark title '%s', got '%s'", expectedTitle, report.Benchmark.Title)
+			}
+			if len(report.Benchmark.Rules) != 2 {
+				t.Fatalf("Expected 2 rules, got %d", len(report.Benchmark.Rules))
+			}
+			if len(report.Benchmark.Values) != 2 {
+				t.Errorf("Expected 2 values, got %d", len(report.Benchmark.Values))
+			}
+
+			rule := report.Benchmark.Rules[timeoutRuleID]
+			if rule == nil {
+				t.Fatalf("Expected rule %s to be parsed", timeoutRuleID)
+			}
+			if rule.Severity != "medium" {
+				t.Errorf("Expected severity 'medium', got '%s'", rule.Severity)
+			}
+			if len(rule.Idents) != 1 || rule.Idents[0].Value != "CCE-83000-1" {
+				t.Errorf("Unexpected identifiers %v", rule.Idents)
+			}
+			if len(rule.Checks) != 1 || len(rule.Checks[0].ContentRefs) != 1 {
+				t.Errorf("Unexpected checks %v", rule.Checks)
+			}
+
+			res := report.TestResult
+			if res.Profile.IDRef != "xccdf_org.ssgproject.content_profile_cis" {
+				t.Errorf("Unexpected profile '%s'", res.Profile.IDRef)
+			}
+			if res.Target != "ocp4-cis-api-checks-pod" {
+				t.Errorf("Unexpected target '%s'", res.Target)
+			}
+			if len(res.RuleResults) != 2 {
+				t.Fatalf("Expected 2 rule results, got %d", len(res.RuleResults))
+			}
+			if res.RuleResults[0].IDRef != timeoutRuleID || res.RuleResults[0].Result != "fail" {
+				t.Errorf("Unexpected rule result %v", res.RuleResults[0])
+			}
+			if len(res.Scores) != 1 || res.Scores[0].Value != "50.000000" {
+				t.Errorf("Unexpected scores %v", res.Scores)
+			}
+		})
+	}
+}
+
+func TestParseResolvesSubstitutions(t *testing.T) {
+	report, err := ParseFile("testdata/report.xml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, tc := range []struct {
+		desc     string
+		text     Text
+		expected string
+	}{
+		{
+			desc:     "value set by the profile",
+			text:     report.Benchmark.Rules[timeoutRuleID].Title,
+			expected: "Ensure the request timeout is 120s",
+		},
+		{
+			desc:     "value next to markup",
+			text:     report.Benchmark.Rules[timeoutRuleID].Description,
+			expected: "Set min-request-timeout to 120s.",
+		},
+		{
+			desc:     "title of the value",
+			text:     report.Benchmark.Rules[timeoutRuleID].Rationale,
+			expected: "The API Server Request Timeout limits long running requests.",
+		},
+		{
+			desc:     "default value",
+			text:     report.Benchmark.Rules[auditRuleID].Title,
+			expected: "Ensure the audit profile is Default",
+		},
+		{
+			desc:     "unknown value",
+			text:     report.Benchmark.Rules[auditRuleID].Description,
+			expected: "Uses xccdf_org.ssgproject.content_value_var_unknown.",
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.text.String() != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, tc.text)
+			}
+		})
+	}
+}
+
+func TestParseRequiresTestResult(t *testing.T) {
+	doc := `<Benchmark xmlns="http://checklists.nist.gov/xccdf/1.2" id="benchmark"><title>Benchmark</title></Benchmark>`
+	if _, err := Parse(strings.NewReader(doc)); err == nil {
+		t.Fatalf("Expected a document without a TestResult to be rejected")
+	}
+}
@@ -0,0 +1,185 @@
+package arf
+
+import (
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+)
+
+// NotSelectedResult is the result of the rules that weren't part of the
+// evaluated profile
+const NotSelectedResult = "notselected"
+
+// resultOrder is the order in which the results are listed in the report.
+// The most relevant results come first.
+var resultOrder = map[string]int{
+	"fail":          0,
+	"error":         1,
+	"unknown":       2,
+	"notchecked":    3,
+	"informational": 4,
+	"pass":          5,
+	"fixed":         6,
+	"notapplicable": 7,
+}
+
+var severityOrder = map[string]int{
+	"high":    0,
+	"medium":  1,
+	"low":     2,
+	"unknown": 3,
+	"info":    4,
+}
+
+// htmlRuleResult is the information rendered for each rule result
+type htmlRuleResult struct {
+	RuleResult
+	Rule *Rule
+}
+
+func (r htmlRuleResult) Title() string {
+	if r.Rule != nil && r.Rule.Title != "" {
+		return r.Rule.Title.String()
+	}
+	return r.IDRef
+}
+
+type htmlReport struct {
+	Benchmark  *Benchmark
+	TestResult *TestResult
+	Counts     []htmlCount
+	Results    []htmlRuleResult
+}
+
+type htmlCount struct {
+	Result string
+	Count  int
+}
+
+// WriteHTML renders the report as a standalone HTML document. Rules that
+// weren't selected in the evaluated profile are left out.
+func (r *Report) WriteHTML(w io.Writer) error {
+	data := htmlReport{
+		Benchmark:  &r.Benchmark,
+		TestResult: &r.TestResult,
+	}
+
+	counts := map[string]int{}
+	for _, rr := range r.TestResult.RuleResults {
+		if rr.Result == NotSelectedResult {
+			continue
+		}
+		counts[rr.Result]++
+		data.Results = append(data.Results, htmlRuleResult{
+			RuleResult: rr,
+			Rule:       r.Benchmark.Rules[rr.IDRef],
+		})
+	}
+
+	sort.SliceStable(data.Results, func(i, j int) bool {
+		a, b := data.Results[i], data.Results[j]
+		if rank(resultOrder, a.Result) != rank(resultOrder, b.Result) {
+			return rank(resultOrder, a.Result) < rank(resultOrder, b.Result)
+		}
+		return rank(severityOrder, a.Severity) < rank(severityOrder, b.Severity)
+	})
+
+	for result, count := range counts {
+		data.Counts = append(data.Counts, htmlCount{result, count})
+	}
+	sort.Slice(data.Counts, func(i, j int) bool {
+		return rank(resultOrder, data.Counts[i].Result) < rank(resultOrder, data.Counts[j].Result)
+	})
+
+	return htmlTemplate.Execute(w, data)
+}
+
+// rank gets the position of the key in the given order. Unknown keys go last.
+func rank(order map[string]int, key string) int {
+	if pos, found := order[strings.ToLower(key)]; found {
+		return pos
+	}
+	return len(order)
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ with .Benchmark.Title }}{{ . }}{{ else }}Compliance{{ end }} report for {{ .TestResult.Target }}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; vertical-align: top; }
+th { background: #eee; }
+.result { font-weight: bold; text-transform: uppercase; }
+.result-pass, .result-fixed { color: #2b7a0b; }
+.result-fail, .result-error { color: #b00020; }
+.result-notchecked, .result-unknown, .result-informational { color: #8a6d00; }
+.result-notapplicable { color: #666; }
+details { margin: 0.5em 0 1em 0; }
+.description { white-space: pre-wrap; }
+code { font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>{{ with .Benchmark.Title }}{{ . }}{{ else }}Compliance report{{ end }}</h1>
+<table>
+<tr><th>Target</th><td>{{ .TestResult.Target }}</td></tr>
+<tr><th>Profile</th><td><code>{{ .TestResult.Profile.IDRef }}</code></td></tr>
+<tr><th>Benchmark</th><td><code>{{ .Benchmark.ID }}</code></td></tr>
+<tr><th>Started</th><td>{{ .TestResult.StartTime }}</td></tr>
+<tr><th>Finished</th><td>{{ .TestResult.EndTime }}</td></tr>
+{{- range .TestResult.Scores }}
+<tr><th>Score</th><td>{{ .Value }} / {{ .Maximum }} <small>({{ .System }})</small></td></tr>
+{{- end }}
+</table>
+
+<h2>Results</h2>
+<table>
+<tr>{{ range .Counts }}<th class="result result-{{ .Result }}">{{ .Result }}</th>{{ end }}</tr>
+<tr>{{ range .Counts }}<td>{{ .Count }}</td>{{ end }}</tr>
+</table>
+
+<table>
+<tr><th>Rule</th><th>Severity</th><th>Result</th></tr>
+{{- range $idx, $res := .Results }}
+<tr>
+<td><a href="#rule-{{ $idx }}">{{ $res.Title }}</a></td>
+<td>{{ $res.Severity }}</td>
+<td class="result result-{{ $res.Result }}">{{ $res.Result }}</td>
+</tr>
+{{- end }}
+</table>
+
+<h2>Rule details</h2>
+{{- range $idx, $res := .Results }}
+<h3 id="rule-{{ $idx }}">{{ $res.Title }}</h3>
+<table>
+<tr><th>Rule ID</th><td><code>{{ $res.IDRef }}</code></td></tr>
+<tr><th>Result</th><td class="result result-{{ $res.Result }}">{{ $res.Result }}</td></tr>
+<tr><th>Severity</th><td>{{ $res.Severity }}</td></tr>
+{{- range $res.Idents }}
+<tr><th>Identifier</th><td>{{ .Value }} <small>({{ .System }})</small></td></tr>
+{{- end }}
+{{- range $res.Checks }}{{ $system := .System }}{{ range .ContentRefs }}
+<tr><th>Check</th><td><code>{{ .Name }}</code> <small>({{ $system }})</small></td></tr>
+{{- end }}{{ end }}
+</table>
+{{- with $res.Rule }}
+<details>
+<summary>Description</summary>
+<div class="description">{{ .Description }}</div>
+</details>
+{{- with .Rationale }}
+<details>
+<summary>Rationale</summary>
+<div class="description">{{ . }}</div>
+</details>
+{{- end }}
+{{- end }}
+{{- end }}
+</body>
+</html>
+`))
@@ -3,16 +3,23 @@ package bind
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/spf13/cobra"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	k8sserial "k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/openshift/oc-compliance/internal/common"
 )
 
+// allNodesRole is the ScanSetting role that selects all the nodes
+const allNodesRole = "@all"
+
 type BindContext struct {
 	common.CommandContext
 
@@ -20,6 +27,10 @@ type BindContext struct {
 	Name     string
 	DryRun   bool
 
+	// CreateSettings creates the ScanSetting along with the binding
+	CreateSettings bool
+	NewSettings    ScanSettingOptions
+
 	profilesGVR            schema.GroupVersionResource
 	tailoredProfilesGVR    schema.GroupVersionResource
 	scanSettingsGVR        schema.GroupVersionResource
@@ -41,14 +52,40 @@ func NewBindContext(streams genericclioptions.IOStreams) *BindContext {
 	}
 }
 
+// scanSettingFlags are the flags that set the options of the ScanSetting to
+// create
+var scanSettingFlags = []string{
+	"schedule", "roles", "raw-result-storage-size", "raw-result-storage-rotation", "raw-result-storage-class",
+	"auto-apply-remediations", "auto-update-remediations", "toleration",
+}
+
+// Complete sets all information required for creating the binding
+func (o *BindContext) Complete(cmd *cobra.Command, args []string) error {
+	for _, flag := range scanSettingFlags {
+		if !o.CreateSettings && cmd.Flags().Changed(flag) {
+			return fmt.Errorf("The --%s flag can only be used along with --create-settings", flag)
+		}
+	}
+	return o.CommandContext.Complete(cmd, args)
+}
+
 // Validate ensures that all required arguments and flag values are provided
 func (o *BindContext) Validate() error {
 	if o.Name == "" {
 		return fmt.Errorf("The name parameter is required")
 	}
 
-	if o.Settings == "" {
-		return fmt.Errorf("The settings parameter is required")
+	// A created ScanSetting is named after the binding, unless told otherwise
+	if o.Settings == "" && o.CreateSettings {
+		o.Settings = o.Name
+	} else if o.Settings == "" {
+		o.Settings = "default"
+	}
+
+	if o.CreateSettings {
+		if err := o.NewSettings.Validate(); err != nil {
+			return err
+		}
 	}
 
 	objrefs, err := common.ValidateManyObjectArgs(o.Args)
@@ -57,7 +94,7 @@ func (o *BindContext) Validate() error {
 	}
 
 	// Validate types
-	for _, obj := range o.objects {
+	for _, obj := range objrefs {
 		switch obj.Type {
 		case common.Profile:
 			continue
@@ -68,10 +105,154 @@ func (o *BindContext) Validate() error {
 		}
 	}
 
-	// TODO(jaosorior): Validate that objects actually exist
-
 	o.objects = objrefs
-	return nil
+	return o.validateObjects()
+}
+
+// validateObjects verifies that the ScanSetting and the profiles exist, and
+// that they can be bound together. All the problems are reported at once.
+func (o *BindContext) validateObjects() error {
+	cache := common.NewObjectCache(o.Kuser)
+	errs := []error{}
+
+	// The roles are only checked if they're known
+	var roles []string
+	checkRoles := false
+	setting, err := cache.Get(o.scanSettingsGVR, o.Settings)
+	if o.CreateSettings {
+		if err == nil {
+			errs = append(errs, fmt.Errorf("The ScanSetting '%s' already exists", o.Settings))
+		} else if !kerrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("Unable to get resource %s/%s of type %s: %s", o.Kuser.GetNamespace(), o.Settings, "ScanSetting", err))
+		}
+		roles = o.NewSettings.Roles
+		checkRoles = true
+	} else if kerrors.IsNotFound(err) {
+		errs = append(errs, fmt.Errorf("The ScanSetting '%s' doesn't exist", o.Settings))
+	} else if err != nil {
+		errs = append(errs, fmt.Errorf("Unable to get resource %s/%s of type %s: %s", o.Kuser.GetNamespace(), o.Settings, "ScanSetting", err))
+	} else {
+		roles, _, _ = unstructured.NestedStringSlice(setting.Object, "roles")
+		checkRoles = true
+	}
+
+	nodeProfiles := []string{}
+	bundles := map[string]bool{}
+	for _, obj := range o.objects {
+		productType, bundle, err := o.getProductTypeAndBundle(cache, obj)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if productType == common.ProductTypeNode {
+			nodeProfiles = append(nodeProfiles, obj.Name)
+		}
+
+		if bundle != "" && !bundles[bundle] {
+			bundles[bundle] = true
+			o.warnIfInvalidBundle(cache, bundle)
+		}
+	}
+
+	if checkRoles && len(nodeProfiles) > 0 {
+		errs = append(errs, o.validateRoles(roles, nodeProfiles)...)
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validateRoles verifies that the roles of the ScanSetting select nodes for
+// the node profiles to scan. Each role that selects no nodes is reported.
+func (o *BindContext) validateRoles(roles, nodeProfiles []string) []error {
+	profiles := strings.Join(nodeProfiles, ", ")
+	if len(roles) == 0 {
+		return []error{fmt.Errorf("%s check nodes, but the ScanSetting '%s' has no roles to scan", profiles, o.Settings)}
+	}
+
+	errs := []error{}
+	for _, role := range roles {
+		// The operator expands this role to all the nodes
+		if role == allNodesRole {
+			continue
+		}
+		nodes, err := o.Kuser.Clientset().CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{
+			LabelSelector: common.NodeRoleLabelPrefix + role,
+			Limit:         1,
+		})
+		if err != nil {
+			// Users that can bind profiles might not be allowed to list
+			// nodes, so this doesn't prevent binding
+			fmt.Fprintf(o.ErrOut, "Warning: Unable to list the nodes of role '%s': %s\n", role, err)
+			continue
+		}
+		if len(nodes.Items) == 0 {
+			errs = append(errs, fmt.Errorf("The role '%s' of the ScanSetting '%s' doesn't select any node, so %s wouldn't scan it", role, o.Settings, profiles))
+		}
+	}
+	return errs
+}
+
+// getProductTypeAndBundle verifies that the given Profile or TailoredProfile
+// exists and is usable, and gets the product type and the ProfileBundle of
+// the content it checks
+func (o *BindContext) getProductTypeAndBundle(cache *common.ObjectCache, obj common.ObjectReference) (string, string, error) {
+	if obj.Type == common.Profile {
+		prof, err := cache.Get(o.profilesGVR, obj.Name)
+		if kerrors.IsNotFound(err) {
+			return "", "", fmt.Errorf("The Profile '%s' doesn't exist", obj.Name)
+		} else if err != nil {
+			return "", "", fmt.Errorf("Unable to get resource %s/%s of type %s: %s", o.Kuser.GetNamespace(), obj.Name, "Profile", err)
+		}
+		return prof.GetAnnotations()[common.ProductTypeAnnotation], prof.GetLabels()[common.ProfileBundleLabel], nil
+	}
+
+	tp, err := cache.Get(o.tailoredProfilesGVR, obj.Name)
+	if kerrors.IsNotFound(err) {
+		return "", "", fmt.Errorf("The TailoredProfile '%s' doesn't exist", obj.Name)
+	} else if err != nil {
+		return "", "", fmt.Errorf("Unable to get resource %s/%s of type %s: %s", o.Kuser.GetNamespace(), obj.Name, "TailoredProfile", err)
+	}
+	state, _, _ := unstructured.NestedString(tp.Object, "status", "state")
+	if state == "ERROR" {
+		msg, _, _ := unstructured.NestedString(tp.Object, "status", "errorMessage")
+		return "", "", fmt.Errorf("The TailoredProfile '%s' is in ERROR state: %s", obj.Name, msg)
+	}
+
+	profName, err := common.GetProfileFromTailoredProfile(tp)
+	if err != nil {
+		return "", "", err
+	}
+	if profName == "" {
+		// TailoredProfiles that don't extend a profile carry their own
+		// product type, and their rules tell which bundle they come from
+		bundle, err := common.GetBundleFromTailoredProfileRules(cache, tp)
+		if err != nil {
+			return "", "", err
+		}
+		return tp.GetAnnotations()[common.ProductTypeAnnotation], bundle, nil
+	}
+
+	prof, err := cache.Get(o.profilesGVR, profName)
+	if kerrors.IsNotFound(err) {
+		return "", "", fmt.Errorf("The Profile '%s' extended by the TailoredProfile '%s' doesn't exist", profName, obj.Name)
+	} else if err != nil {
+		return "", "", fmt.Errorf("Unable to get resource %s/%s of type %s: %s", o.Kuser.GetNamespace(), profName, "Profile", err)
+	}
+	return prof.GetAnnotations()[common.ProductTypeAnnotation], prof.GetLabels()[common.ProfileBundleLabel], nil
+}
+
+// warnIfInvalidBundle warns if the content of the given ProfileBundle
+// couldn't be parsed, as its profiles might be outdated
+func (o *BindContext) warnIfInvalidBundle(cache *common.ObjectCache, bundle string) {
+	pb, err := cache.Get(common.GVR("profilebundles"), bundle)
+	if err != nil {
+		fmt.Fprintf(o.ErrOut, "Warning: Unable to get ProfileBundle '%s': %s\n", bundle, err)
+		return
+	}
+	status, _, _ := unstructured.NestedString(pb.Object, "status", "dataStreamStatus")
+	if status != "VALID" {
+		fmt.Fprintf(o.ErrOut, "Warning: The ProfileBundle '%s' is not VALID (status: %s). Its profiles might not be scanned as expected\n", bundle, status)
+	}
 }
 
 func (o *BindContext) Run() error {
@@ -121,16 +302,44 @@ func (o *BindContext) Run() error {
 		return err
 	}
 
+	var scanSetting *unstructured.Unstructured
+	if o.CreateSettings {
+		var err error
+		if scanSetting, err = o.NewSettings.Build(o.Settings); err != nil {
+			return err
+		}
+	}
+
 	if o.DryRun {
 		yamlSerializer := k8sserial.NewYAMLSerializer(k8sserial.DefaultMetaFactory, nil, nil)
-		common.PersistObjectToYaml(o.Name, scanSettingBinding, o.Out, yamlSerializer)
-	} else {
-		fmt.Fprintf(o.Out, "Creating ScanSettingBinding %s\n", o.Name)
-		_, err := o.Kuser.DynamicClient().Resource(o.scanSettingBindingsGVR).Namespace(o.Kuser.GetNamespace()).Create(
-			context.TODO(), scanSettingBinding, metav1.CreateOptions{})
+		if scanSetting != nil {
+			if err := common.PersistObjectToYaml(o.Settings, scanSetting, o.Out, yamlSerializer); err != nil {
+				return err
+			}
+		}
+		return common.PersistObjectToYaml(o.Name, scanSettingBinding, o.Out, yamlSerializer)
+	}
+
+	if scanSetting != nil {
+		fmt.Fprintf(o.Out, "Creating ScanSetting %s\n", o.Settings)
+		_, err := o.Kuser.DynamicClient().Resource(o.scanSettingsGVR).Namespace(o.Kuser.GetNamespace()).Create(
+			context.TODO(), scanSetting, metav1.CreateOptions{})
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+
+	fmt.Fprintf(o.Out, "Creating ScanSettingBinding %s\n", o.Name)
+	_, err := o.Kuser.DynamicClient().Resource(o.scanSettingBindingsGVR).Namespace(o.Kuser.GetNamespace()).Create(
+		context.TODO(), scanSettingBinding, metav1.CreateOptions{})
+	if err != nil && scanSetting != nil {
+		// Don't leave the ScanSetting behind, so the command can be retried
+		fmt.Fprintf(o.Out, "Deleting ScanSetting %s\n", o.Settings)
+		delErr := o.Kuser.DynamicClient().Resource(o.scanSettingsGVR).Namespace(o.Kuser.GetNamespace()).Delete(
+			context.TODO(), o.Settings, metav1.DeleteOptions{})
+		if delErr != nil {
+			return fmt.Errorf("%s. Unable to delete the ScanSetting %s created for it: %s", err, o.Settings, delErr)
+		}
+	}
+	return err
 }
@@ -0,0 +1,135 @@
+package bind
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+// ScanSettingOptions are the settings of a ScanSetting created along with
+// the binding
+type ScanSettingOptions struct {
+	Schedule                 string
+	Roles                    []string
+	RawResultStorageSize     string
+	RawResultStorageRotation int
+	RawResultStorageClass    string
+	AutoApplyRemediations    bool
+	AutoUpdateRemediations   bool
+	// Tolerations of the scan pods, as <key>[=<value>][:<effect>]
+	Tolerations []string
+}
+
+var validTaintEffects = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+
+// Validate ensures that the settings are well formed
+func (s *ScanSettingOptions) Validate() error {
+	if len(strings.Fields(s.Schedule)) != 5 && !strings.HasPrefix(s.Schedule, "@") {
+		return fmt.Errorf("Invalid schedule '%s'. Must be a cron expression", s.Schedule)
+	}
+	for _, role := range s.Roles {
+		if role == "" {
+			return fmt.Errorf("The roles can't be empty")
+		}
+	}
+	if _, err := resource.ParseQuantity(s.RawResultStorageSize); err != nil {
+		return fmt.Errorf("Invalid raw result storage size '%s': %s", s.RawResultStorageSize, err)
+	}
+	if s.RawResultStorageRotation < 0 {
+		return fmt.Errorf("The raw result storage rotation can't be negative")
+	}
+	for _, tol := range s.Tolerations {
+		if _, err := parseToleration(tol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseToleration parses a toleration given as <key>[=<value>][:<effect>],
+// like the taints of 'oc adm taint'. If no value is given, any value of the
+// key is tolerated.
+func parseToleration(tol string) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	keyValue := tol
+	if idx := strings.LastIndex(tol, ":"); idx != -1 {
+		effect := tol[idx+1:]
+		found := false
+		for _, validEffect := range validTaintEffects {
+			if effect == validEffect {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("Invalid effect in toleration '%s'. Must be one of: %s", tol, strings.Join(validTaintEffects, ", "))
+		}
+		out["effect"] = effect
+		keyValue = tol[:idx]
+	}
+
+	parts := strings.SplitN(keyValue, "=", 2)
+	if parts[0] == "" {
+		return nil, fmt.Errorf("Malformed toleration '%s'. Must be <key>[=<value>][:<effect>]", tol)
+	}
+	out["key"] = parts[0]
+	if len(parts) == 2 {
+		out["operator"] = "Equal"
+		out["value"] = parts[1]
+	} else {
+		out["operator"] = "Exists"
+	}
+	return out, nil
+}
+
+// Build creates the ScanSetting object with the given name
+func (s *ScanSettingOptions) Build(name string) (*unstructured.Unstructured, error) {
+	scanSetting := &unstructured.Unstructured{}
+	ssRaw := scanSetting.UnstructuredContent()
+	scanSetting.SetUnstructuredContent(ssRaw)
+	scanSetting.SetName(name)
+	scanSetting.SetGroupVersionKind(
+		schema.GroupVersionKind{
+			Group:   common.CmpAPIGroup,
+			Version: common.CmpResourceVersion,
+			Kind:    "ScanSetting",
+		})
+
+	ssRaw["schedule"] = s.Schedule
+	ssRaw["autoApplyRemediations"] = s.AutoApplyRemediations
+	ssRaw["autoUpdateRemediations"] = s.AutoUpdateRemediations
+	if err := unstructured.SetNestedStringSlice(ssRaw, s.Roles, "roles"); err != nil {
+		return nil, err
+	}
+
+	storage := map[string]interface{}{
+		"size":     s.RawResultStorageSize,
+		"rotation": int64(s.RawResultStorageRotation),
+	}
+	if s.RawResultStorageClass != "" {
+		storage["storageClassName"] = s.RawResultStorageClass
+	}
+	if err := unstructured.SetNestedMap(ssRaw, storage, "rawResultStorage"); err != nil {
+		return nil, err
+	}
+
+	if len(s.Tolerations) > 0 {
+		tolerations := []interface{}{}
+		for _, rawTol := range s.Tolerations {
+			tol, err := parseToleration(rawTol)
+			if err != nil {
+				return nil, err
+			}
+			tolerations = append(tolerations, tol)
+		}
+		if err := unstructured.SetNestedSlice(ssRaw, tolerations, "scanTolerations"); err != nil {
+			return nil, err
+		}
+	}
+	return scanSetting, nil
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ProfileBundleLabel is set by the operator on the profiles and rules parsed
+// from a ProfileBundle
+const ProfileBundleLabel = "compliance.openshift.io/profile-bundle"
+
+// listPageSize is the number of objects fetched per request when listing
+const listPageSize = 500
+
+// ObjectCache fetches objects from the current namespace and keeps them
+// around, so objects shared by several results (scans, suites, bindings,
+// profiles and rules) are only fetched once. When many objects of the same
+// type are needed (e.g. the rules of a profile), they can be prefetched with
+// a single List instead of a Get per object.
+type ObjectCache struct {
+	kuser KubeClientUser
+	objs  map[string]*unstructured.Unstructured
+	// lists that were already prefetched
+	listed map[string]bool
+}
+
+func NewObjectCache(kuser KubeClientUser) *ObjectCache {
+	return &ObjectCache{
+		kuser:  kuser,
+		objs:   map[string]*unstructured.Unstructured{},
+		listed: map[string]bool{},
+	}
+}
+
+// Prefetch lists the objects of the given resource that match the label
+// selector, so they're served from memory afterwards. The list is paginated
+// to keep the responses of the API server small.
+func (c *ObjectCache) Prefetch(gvr schema.GroupVersionResource, labelSelector string) error {
+	listKey := fmt.Sprintf("%s?%s", gvr.String(), labelSelector)
+	if c.listed[listKey] || c.listed[gvr.String()+"?"] {
+		return nil
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: labelSelector,
+		Limit:         listPageSize,
+	}
+	for {
+		list, err := c.kuser.DynamicClient().Resource(gvr).Namespace(c.kuser.GetNamespace()).List(context.TODO(), opts)
+		if err != nil {
+			return fmt.Errorf("Unable to list %s: %s", gvr.Resource, err)
+		}
+		for idx := range list.Items {
+			obj := &list.Items[idx]
+			c.objs[fmt.Sprintf("%s/%s", gvr.String(), obj.GetName())] = obj
+		}
+		if list.GetContinue() == "" {
+			break
+		}
+		opts.Continue = list.GetContinue()
+	}
+	c.listed[listKey] = true
+	return nil
+}
+
+// PrefetchRules lists the rules of the given profile bundle. If the bundle
+// is empty, all the rules are listed.
+func (c *ObjectCache) PrefetchRules(bundle string) error {
+	selector := ""
+	if bundle != "" {
+		selector = fmt.Sprintf("%s=%s", ProfileBundleLabel, bundle)
+	}
+	return c.Prefetch(GVR("rules"), selector)
+}
+
+// Get fetches the object with the given name, unless it was already fetched
+func (c *ObjectCache) Get(gvr schema.GroupVersionResource, name string) (*unstructured.Unstructured, error) {
+	key := fmt.Sprintf("%s/%s", gvr.String(), name)
+	if obj, found := c.objs[key]; found {
+		return obj, nil
+	}
+	obj, err := c.kuser.DynamicClient().Resource(gvr).Namespace(c.kuser.GetNamespace()).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	c.objs[key] = obj
+	return obj, nil
+}
+
+// List fetches all the objects of the given resource, sorted by name
+func (c *ObjectCache) List(gvr schema.GroupVersionResource) ([]*unstructured.Unstructured, error) {
+	if err := c.Prefetch(gvr, ""); err != nil {
+		return nil, err
+	}
+	prefix := gvr.String() + "/"
+	objs := []*unstructured.Unstructured{}
+	for key, obj := range c.objs {
+		if strings.HasPrefix(key, prefix) {
+			objs = append(objs, obj)
+		}
+	}
+	sort.Slice(objs, func(i, j int) bool { return objs[i].GetName() < objs[j].GetName() })
+	return objs, nil
+}
+
+// GetControllerOf fetches the object that controls the given one
+func (c *ObjectCache) GetControllerOf(res *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	ctrl := metav1.GetControllerOf(res)
+	if ctrl == nil {
+		return nil, fmt.Errorf("the object had no owner")
+	}
+
+	gvr := GetGVRFromAPIVersionAndKind(ctrl.APIVersion, ctrl.Kind)
+	return c.Get(gvr, ctrl.Name)
+}
@@ -0,0 +1,27 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// ScanNameLabel is set by the operator on the objects generated from a scan
+	ScanNameLabel = "compliance.openshift.io/scan-name"
+	// SuiteLabel is set by the operator on the objects generated from a suite
+	SuiteLabel = "compliance.openshift.io/suite"
+)
+
+// GetCheckResultsFromScan gets the ComplianceCheckResults that were generated by a ComplianceScan
+func GetCheckResultsFromScan(kuser KubeClientUser, scanName string) ([]unstructured.Unstructured, error) {
+	list, err := kuser.DynamicClient().Resource(GVR("compliancecheckresults")).Namespace(kuser.GetNamespace()).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", ScanNameLabel, scanName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get results of scan %s/%s: %s", kuser.GetNamespace(), scanName, err)
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"fmt"
+)
+
+const (
+	// ExitCodeNonCompliant is returned when the scanned objects aren't
+	// compliant
+	ExitCodeNonCompliant = 2
+	// ExitCodeScanError is returned when the scans couldn't determine
+	// whether the objects are compliant (e.g. ERROR or INCONSISTENT results)
+	ExitCodeScanError = 3
+)
+
+// ExitCodeError is an error that makes the command exit with a specific code,
+// so scripts can tell the reasons of a failure apart
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func NewExitCodeError(code int, format string, args ...interface{}) error {
+	return &ExitCodeError{
+		Code: code,
+		Err:  fmt.Errorf(format, args...),
+	}
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
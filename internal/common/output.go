@@ -0,0 +1,87 @@
+package common
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Output formats that commands may support through the --output flag
+const (
+	OutputFormatTable = "table"
+	OutputFormatJSON  = "json"
+	OutputFormatYAML  = "yaml"
+	// Tabular formats, for data that is a list of rows
+	OutputFormatCSV      = "csv"
+	OutputFormatMarkdown = "markdown"
+)
+
+// ValidateOutputFormat ensures that the given output format is one of the
+// allowed ones
+func ValidateOutputFormat(format string, allowed ...string) error {
+	for _, a := range allowed {
+		if format == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid output format '%s'. Must be one of: %s", format, strings.Join(allowed, "|"))
+}
+
+// PrintStructured renders the given object as JSON or YAML to the given writer
+func PrintStructured(w io.Writer, format string, obj interface{}) error {
+	switch format {
+	case OutputFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(obj); err != nil {
+			return fmt.Errorf("Couldn't serialize JSON output: %s", err)
+		}
+	case OutputFormatYAML:
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("Couldn't serialize YAML output: %s", err)
+		}
+		if _, err := w.Write(out); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("Output format '%s' can't be used for structured output", format)
+	}
+	return nil
+}
+
+// PrintTabular renders the given rows as CSV or as a Markdown table to the
+// given writer
+func PrintTabular(w io.Writer, format string, header []string, rows [][]string) error {
+	switch format {
+	case OutputFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(rows); err != nil {
+			return fmt.Errorf("Couldn't write CSV output: %s", err)
+		}
+	case OutputFormatMarkdown:
+		separator := make([]string, len(header))
+		for idx := range separator {
+			separator[idx] = "---"
+		}
+		for _, row := range append([][]string{header, separator}, rows...) {
+			cells := make([]string, len(row))
+			for idx, cell := range row {
+				cells[idx] = strings.ReplaceAll(cell, "|", "\\|")
+			}
+			if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | ")); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("Output format '%s' can't be used for tabular output", format)
+	}
+	return nil
+}
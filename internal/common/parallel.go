@@ -0,0 +1,131 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// errSkipped marks the items that weren't processed because a previous one
+// failed
+var errSkipped = errors.New("skipped after a previous error")
+
+// ParallelOptions controls how a set of objects (e.g. the scans of a suite)
+// is processed
+type ParallelOptions struct {
+	// Parallelism is the maximum number of objects processed at once
+	Parallelism int
+	// ContinueOnError keeps processing the rest of the objects after one
+	// of them failed. Otherwise, no more objects are started.
+	ContinueOnError bool
+}
+
+// Validate ensures that the options are usable
+func (o ParallelOptions) Validate() error {
+	if o.Parallelism < 1 {
+		return fmt.Errorf("The parallelism must be at least 1")
+	}
+	return nil
+}
+
+// RunParallel calls fn for each of the given names, running at most
+// opts.Parallelism calls at once. It returns the error of each call, in the
+// same order as the names.
+func RunParallel(names []string, opts ParallelOptions, fn func(name string) error) []error {
+	errs := make([]error, len(names))
+	parallelism := opts.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	failed := false
+	for idx, name := range names {
+		sem <- struct{}{}
+		mu.Lock()
+		stop := failed && !opts.ContinueOnError
+		mu.Unlock()
+		if stop {
+			<-sem
+			errs[idx] = errSkipped
+			continue
+		}
+
+		wg.Add(1)
+		go func(idx int, name string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(name); err != nil {
+				mu.Lock()
+				failed = true
+				mu.Unlock()
+				errs[idx] = err
+			}
+		}(idx, name)
+	}
+	wg.Wait()
+	return errs
+}
+
+// SummarizeErrors prints how many of the objects of the given kind were
+// processed successfully, and aggregates the errors of the ones that failed
+// after the given description of the failure. The error of a single object
+// is returned as is, since it needs no summary.
+func SummarizeErrors(w io.Writer, kind string, names []string, errs []error, failure string) error {
+	if len(names) == 1 {
+		return errs[0]
+	}
+
+	failed := []string{}
+	skipped := []string{}
+	aggregate := []error{}
+	for idx, err := range errs {
+		switch {
+		case err == nil:
+			continue
+		case err == errSkipped:
+			skipped = append(skipped, names[idx])
+		default:
+			failed = append(failed, names[idx])
+			aggregate = append(aggregate, fmt.Errorf("%s %s: %s", kind, names[idx], err))
+		}
+	}
+
+	fmt.Fprintf(w, "Processed %d %ss: %d succeeded", len(names), kind, len(names)-len(failed)-len(skipped))
+	if len(failed) > 0 {
+		fmt.Fprintf(w, ", %d failed (%s)", len(failed), strings.Join(failed, ", "))
+	}
+	if len(skipped) > 0 {
+		fmt.Fprintf(w, ", %d skipped (%s)", len(skipped), strings.Join(skipped, ", "))
+	}
+	fmt.Fprint(w, "\n")
+
+	if len(aggregate) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %s", failure, utilerrors.NewAggregate(aggregate))
+}
+
+// syncWriter serializes the writes of concurrent users of a writer, so
+// their lines aren't garbled
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewSyncWriter wraps the given writer so it can be used concurrently
+func NewSyncWriter(w io.Writer) io.Writer {
+	return &syncWriter{w: w}
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
@@ -0,0 +1,415 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// RuleAnnotationKey is set on rules and results with the rule's reference
+// in the content
+const RuleAnnotationKey = "compliance.openshift.io/rule"
+
+// ProfileHandler abstracts the differences between Profiles and
+// TailoredProfiles when resolving the rules a scan used
+type ProfileHandler interface {
+	// GetObject gets the Profile or TailoredProfile object
+	GetObject() *unstructured.Unstructured
+	ProfileMatches(ScanProfileID) bool
+	FindRule(string) (*unstructured.Unstructured, error)
+	// GetRules gets the names of the rules that the profile effectively
+	// checks
+	GetRules() ([]string, error)
+	// GetVariables gets the values of the variables that the profile
+	// effectively uses, indexed by variable name
+	GetVariables() (map[string]string, error)
+}
+
+// NewProfileHandler gets the handler for a Profile or TailoredProfile
+// object. The parent is only used for error reporting.
+func NewProfileHandler(obj *unstructured.Unstructured, parent string, c *ObjectCache) (ProfileHandler, error) {
+	rulegvr := GVR("rules")
+
+	switch obj.GetKind() {
+	case "Profile":
+		return &profileHandlerImpl{c, obj, rulegvr}, nil
+	case "TailoredProfile":
+		return &tailoredProfileHandlerImpl{c, obj, rulegvr, nil}, nil
+	}
+	return nil, fmt.Errorf("Got unkown type for profile '%s' in parent object '%s'", obj.GetName(), parent)
+}
+
+type profileHandlerImpl struct {
+	cache   *ObjectCache
+	obj     *unstructured.Unstructured
+	rulegvr schema.GroupVersionResource
+}
+
+func (ph *profileHandlerImpl) GetObject() *unstructured.Unstructured {
+	return ph.obj
+}
+
+func (ph *profileHandlerImpl) ProfileMatches(spi ScanProfileID) bool {
+	objid, found, err := unstructured.NestedString(ph.obj.Object, "id")
+	if err != nil || !found {
+		return false
+	}
+	pb, err := ph.cache.GetControllerOf(ph.obj)
+	if err != nil {
+		// TODO(jaosorior): Should probably issue a warning
+		return false
+	}
+	contentFile, found, err := unstructured.NestedString(pb.Object, "spec", "contentFile")
+	if err != nil || !found {
+		// TODO(jaosorior): Should probably issue a warning
+		return false
+	}
+	profspi := ScanProfileID{contentFile, objid}
+	return spi.IsEqual(profspi)
+}
+
+func (ph *profileHandlerImpl) FindRule(ruleRef string) (*unstructured.Unstructured, error) {
+	rules, err := GetRulesFromProfile(ph.obj)
+	if err != nil {
+		return nil, err
+	}
+	return findRule(ph.cache, ph.rulegvr, rules, ruleRef)
+}
+
+// findRule looks for the rule with the given reference in the content among
+// the rules with the given names
+func findRule(cache *ObjectCache, rulegvr schema.GroupVersionResource, rules []string, ruleRef string) (*unstructured.Unstructured, error) {
+	for _, rule := range rules {
+		// OPTIMIZATION: don't fetch the rule, the annotation should be a
+		// substring of the rule name
+		if !strings.Contains(rule, ruleRef) {
+			continue
+		}
+		ruleobj, err := cache.Get(rulegvr, rule)
+		if err != nil {
+			return nil, err
+		}
+		ruleAnns := ruleobj.GetAnnotations()
+		// TODO(jaosorior): Should a warning be printed if a rule has no annotatins?
+		if ruleAnns == nil {
+			continue
+		}
+		if ruleRef == ruleAnns[RuleAnnotationKey] {
+			return ruleobj, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Didn't find relevant rule for extra information")
+}
+
+func (ph *profileHandlerImpl) GetRules() ([]string, error) {
+	return GetRulesFromProfile(ph.obj)
+}
+
+func (ph *profileHandlerImpl) GetVariables() (map[string]string, error) {
+	names, found, err := unstructured.NestedStringSlice(ph.obj.Object, "values")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get values of %s/%s of type %s: %s", ph.obj.GetNamespace(), ph.obj.GetName(), ph.obj.GetKind(), err)
+	}
+	vars := map[string]string{}
+	if !found || len(names) == 0 {
+		return vars, nil
+	}
+
+	if err := ph.cache.Prefetch(GVR("variables"), ""); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		variable, err := ph.cache.Get(GVR("variables"), name)
+		if err != nil {
+			return nil, err
+		}
+		vars[name], _, _ = unstructured.NestedString(variable.Object, "value")
+	}
+	return vars, nil
+}
+
+type tailoredProfileHandlerImpl struct {
+	cache         *ObjectCache
+	obj           *unstructured.Unstructured
+	rulegvr       schema.GroupVersionResource
+	parentProfile *unstructured.Unstructured
+}
+
+func (tph *tailoredProfileHandlerImpl) GetObject() *unstructured.Unstructured {
+	return tph.obj
+}
+
+func (tph *tailoredProfileHandlerImpl) ProfileMatches(spi ScanProfileID) bool {
+	objid, found, err := unstructured.NestedString(tph.obj.Object, "status", "id")
+	if err != nil || !found {
+		return false
+	}
+	pb, err := tph.getProfileBundle()
+	if err != nil {
+		// TODO(jaosorior): Should probably issue a warning
+		return false
+	}
+	contentFile, found, err := unstructured.NestedString(pb.Object, "spec", "contentFile")
+	if err != nil || !found {
+		// TODO(jaosorior): Should probably issue a warning
+		return false
+	}
+	profspi := ScanProfileID{contentFile, objid}
+	return spi.IsEqual(profspi)
+}
+
+// FindRule looks for the rule among the ones that the TailoredProfile
+// effectively checks
+func (tph *tailoredProfileHandlerImpl) FindRule(ruleRef string) (*unstructured.Unstructured, error) {
+	rules, err := tph.GetRules()
+	if err != nil {
+		return nil, err
+	}
+	return findRule(tph.cache, tph.rulegvr, rules, ruleRef)
+}
+
+// GetRules gets the rules of the extended profile, if any, plus the enabled
+// and manual rules and minus the disabled ones. The order of the extended
+// profile is kept.
+func (tph *tailoredProfileHandlerImpl) GetRules() ([]string, error) {
+	prof, err := tph.getParentProfile()
+	if err != nil {
+		return nil, err
+	}
+	var parentRules []string
+	if prof != nil {
+		if parentRules, err = GetRulesFromProfile(prof); err != nil {
+			return nil, err
+		}
+	}
+	enabled, err := getRuleSelections(tph.obj, "enableRules")
+	if err != nil {
+		return nil, err
+	}
+	manual, err := getRuleSelections(tph.obj, "manualRules")
+	if err != nil {
+		return nil, err
+	}
+	disabled, err := getRuleSelections(tph.obj, "disableRules")
+	if err != nil {
+		return nil, err
+	}
+
+	isDisabled := map[string]bool{}
+	for _, rule := range disabled {
+		isDisabled[rule] = true
+	}
+	seen := map[string]bool{}
+	rules := []string{}
+	for _, rule := range append(append(parentRules, enabled...), manual...) {
+		if isDisabled[rule] || seen[rule] {
+			continue
+		}
+		seen[rule] = true
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// GetVariables gets the variables of the extended profile, if any, with the
+// values that the TailoredProfile sets
+func (tph *tailoredProfileHandlerImpl) GetVariables() (map[string]string, error) {
+	prof, err := tph.getParentProfile()
+	if err != nil {
+		return nil, err
+	}
+	vars := map[string]string{}
+	if prof != nil {
+		ph, err := NewProfileHandler(prof, tph.obj.GetName(), tph.cache)
+		if err != nil {
+			return nil, err
+		}
+		if vars, err = ph.GetVariables(); err != nil {
+			return nil, err
+		}
+	}
+
+	setValues, found, err := unstructured.NestedSlice(tph.obj.Object, "spec", "setValues")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get setValues of %s/%s of type %s: %s", tph.obj.GetNamespace(), tph.obj.GetName(), tph.obj.GetKind(), err)
+	}
+	if !found {
+		return vars, nil
+	}
+	for _, rawval := range setValues {
+		val, ok := rawval.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Unable to parse setValues of %s/%s of type %s", tph.obj.GetNamespace(), tph.obj.GetName(), tph.obj.GetKind())
+		}
+		name, _, _ := unstructured.NestedString(val, "name")
+		value, _, _ := unstructured.NestedString(val, "value")
+		if name == "" {
+			return nil, fmt.Errorf("%s/%s of type %s: has a value with no name in 'setValues'", tph.obj.GetNamespace(), tph.obj.GetName(), tph.obj.GetKind())
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// getParentProfile gets the extended profile. It's nil for TailoredProfiles
+// that don't extend any profile.
+func (tph *tailoredProfileHandlerImpl) getParentProfile() (*unstructured.Unstructured, error) {
+	if tph.parentProfile != nil {
+		return tph.parentProfile, nil
+	}
+	profname, err := GetProfileFromTailoredProfile(tph.obj)
+	if err != nil || profname == "" {
+		return nil, err
+	}
+	prof, err := tph.cache.Get(GVR("profiles"), profname)
+	if err != nil {
+		return nil, err
+	}
+	tph.parentProfile = prof
+	return tph.parentProfile, nil
+}
+
+// getProfileBundle gets the ProfileBundle that the TailoredProfile's content
+// comes from
+func (tph *tailoredProfileHandlerImpl) getProfileBundle() (*unstructured.Unstructured, error) {
+	prof, err := tph.getParentProfile()
+	if err != nil {
+		return nil, err
+	}
+	if prof != nil {
+		return tph.cache.GetControllerOf(prof)
+	}
+	bundle, err := GetBundleFromTailoredProfileRules(tph.cache, tph.obj)
+	if err != nil {
+		return nil, err
+	}
+	return tph.cache.Get(GVR("profilebundles"), bundle)
+}
+
+// GetBundleFromTailoredProfileRules gets the name of the ProfileBundle that
+// the rules of a TailoredProfile come from. It's meant for TailoredProfiles
+// that don't extend any profile, whose rules all come from the same bundle.
+func GetBundleFromTailoredProfileRules(cache *ObjectCache, tp *unstructured.Unstructured) (string, error) {
+	for _, field := range []string{"enableRules", "manualRules"} {
+		rules, err := getRuleSelections(tp, field)
+		if err != nil {
+			return "", err
+		}
+		for _, name := range rules {
+			rule, err := cache.Get(GVR("rules"), name)
+			if err != nil {
+				return "", fmt.Errorf("Unable to get rule %s of %s/%s of type %s: %s", name, tp.GetNamespace(), tp.GetName(), tp.GetKind(), err)
+			}
+			if bundle := rule.GetLabels()[ProfileBundleLabel]; bundle != "" {
+				return bundle, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%s/%s of type %s: has no rules to get its ProfileBundle from", tp.GetNamespace(), tp.GetName(), tp.GetKind())
+}
+
+// ScanProfileID represents the necessary info to uniquely identify a profile
+type ScanProfileID struct {
+	file string
+	id   string
+}
+
+func (spi ScanProfileID) IsEqual(other ScanProfileID) bool {
+	return spi.file == other.file && spi.id == other.id
+}
+
+// GetScanProfileID gets the ID of the profile that a ComplianceScan evaluates
+func GetScanProfileID(scan *unstructured.Unstructured) (ScanProfileID, error) {
+	scanProfileXCCDFID, err := getProfileIDFromScan(scan)
+	if err != nil {
+		return ScanProfileID{}, err
+	}
+	scanDSFile, err := getDSFromScan(scan)
+	if err != nil {
+		return ScanProfileID{}, err
+	}
+	return ScanProfileID{scanDSFile, scanProfileXCCDFID}, nil
+}
+
+// GetGVRFromProfileRef gets the resource of a profile referenced by a
+// ScanSettingBinding
+func GetGVRFromProfileRef(profRef map[string]interface{}) schema.GroupVersionResource {
+	// NOTE: We wrongly named the apiVersion to be apiGroup
+	apiVersion := profRef["apiGroup"].(string)
+	kind := profRef["kind"].(string)
+	return GetGVRFromAPIVersionAndKind(apiVersion, kind)
+}
+
+func GetGVRFromAPIVersionAndKind(apiVersion, kind string) schema.GroupVersionResource {
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	return gvk.GroupVersion().WithResource(pluralizeKind(gvk.Kind))
+}
+
+func pluralizeKind(kind string) string {
+	ret := strings.ToLower(kind)
+	if strings.HasSuffix(ret, "s") {
+		return fmt.Sprintf("%ses", ret)
+	}
+	return fmt.Sprintf("%ss", ret)
+}
+
+func getProfileIDFromScan(obj *unstructured.Unstructured) (string, error) {
+	id, found, err := unstructured.NestedString(obj.Object, "spec", "profile")
+	if err != nil {
+		return "", fmt.Errorf("Unable to get profile id of %s/%s of type %s: %s", obj.GetNamespace(), obj.GetName(), "ComplianceScan", err)
+	}
+	if !found {
+		return "", fmt.Errorf("%s/%s of type %s: has no 'profile'", obj.GetNamespace(), obj.GetName(), "ComplianceScan")
+	}
+	return id, nil
+}
+
+func getDSFromScan(obj *unstructured.Unstructured) (string, error) {
+	fil, found, err := unstructured.NestedString(obj.Object, "spec", "content")
+	if err != nil {
+		return "", fmt.Errorf("Unable to get content file reference of %s/%s of type %s: %s", obj.GetNamespace(), obj.GetName(), "ComplianceScan", err)
+	}
+	if !found {
+		return "", fmt.Errorf("%s/%s of type %s: has no 'content'", obj.GetNamespace(), obj.GetName(), "ComplianceScan")
+	}
+	return fil, nil
+}
+
+// getRuleSelections gets the names of the rules listed in the given field of
+// a TailoredProfile's spec (e.g. enableRules)
+func getRuleSelections(obj *unstructured.Unstructured, field string) ([]string, error) {
+	selections, found, err := unstructured.NestedSlice(obj.Object, "spec", field)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get %s of %s/%s of type %s: %s", field, obj.GetNamespace(), obj.GetName(), obj.GetKind(), err)
+	}
+	if !found {
+		return nil, nil
+	}
+	names := []string{}
+	for _, rawsel := range selections {
+		sel, ok := rawsel.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Unable to parse %s of %s/%s of type %s", field, obj.GetNamespace(), obj.GetName(), obj.GetKind())
+		}
+		name, found, err := unstructured.NestedString(sel, "name")
+		if err != nil || !found {
+			return nil, fmt.Errorf("%s/%s of type %s: has a rule with no name in '%s'", obj.GetNamespace(), obj.GetName(), obj.GetKind(), field)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// GetProfileFromTailoredProfile gets the name of the Profile that a
+// TailoredProfile extends. It's empty for TailoredProfiles written from
+// scratch, which don't extend any profile.
+func GetProfileFromTailoredProfile(obj *unstructured.Unstructured) (string, error) {
+	prof, _, err := unstructured.NestedString(obj.Object, "spec", "extends")
+	if err != nil {
+		return "", fmt.Errorf("Unable to get profile name from %s/%s of type %s: %s", obj.GetNamespace(), obj.GetName(), obj.GetKind(), err)
+	}
+	return prof, nil
+}
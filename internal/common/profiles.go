@@ -16,3 +16,16 @@ func GetRulesFromProfile(obj *unstructured.Unstructured) ([]string, error) {
 	}
 	return rules, nil
 }
+
+// ProductTypeAnnotation is set on profiles to tell whether they check the
+// cluster ("Platform") or its nodes ("Node")
+const ProductTypeAnnotation = "compliance.openshift.io/product-type"
+
+const (
+	ProductTypePlatform = "Platform"
+	ProductTypeNode     = "Node"
+)
+
+// NodeRoleLabelPrefix is the prefix of the node labels that the roles of a
+// ScanSetting select
+const NodeRoleLabelPrefix = "node-role.kubernetes.io/"
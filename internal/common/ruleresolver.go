@@ -0,0 +1,204 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	goerrors "github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ControlAnnotationPrefix is the prefix of the rule annotations that list the
+// controls of a benchmark that the rule addresses
+const ControlAnnotationPrefix = "control.compliance.openshift.io/"
+
+// RuleResolver finds the Rule objects that ComplianceCheckResults were
+// generated from. Results don't reference the Rule object directly, so the
+// rule is looked up in the profile that the result's scan evaluated, which in
+// turn is found through the ScanSettingBinding that owns the scan's suite.
+// Suites that weren't created by a binding have their profile looked up by
+// the scan's profile ID instead.
+type RuleResolver struct {
+	cache *ObjectCache
+	// profile handlers and rules that were already resolved. These are
+	// shared by all the results that come from the same scan.
+	profiles map[ScanProfileID]ProfileHandler
+	rules    map[ruleKey]*unstructured.Unstructured
+}
+
+// ruleKey identifies a rule reference in the context of a specific scan
+type ruleKey struct {
+	spi     ScanProfileID
+	ruleRef string
+}
+
+func NewRuleResolver(kuser KubeClientUser) *RuleResolver {
+	return &RuleResolver{
+		cache:    NewObjectCache(kuser),
+		profiles: map[ScanProfileID]ProfileHandler{},
+		rules:    map[ruleKey]*unstructured.Unstructured{},
+	}
+}
+
+// GetRuleForResult gets the Rule that the given ComplianceCheckResult was
+// generated from
+func (r *RuleResolver) GetRuleForResult(res *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	resultAnns := res.GetAnnotations()
+	if resultAnns == nil {
+		return nil, fmt.Errorf("Result had no annotations, couldn't determine rule.")
+	}
+	ruleRef, ok := resultAnns[RuleAnnotationKey]
+	if !ok {
+		return nil, fmt.Errorf("Malformed result. It doesn't contain a rule reference.")
+	}
+
+	scan, err := r.cache.GetControllerOf(res)
+	if err != nil {
+		return nil, err
+	}
+	spi, err := GetScanProfileID(scan)
+	if err != nil {
+		return nil, err
+	}
+
+	key := ruleKey{spi, ruleRef}
+	if rule, found := r.rules[key]; found {
+		return rule, nil
+	}
+
+	ph, err := r.getProfileForScan(scan, spi)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := ph.FindRule(ruleRef)
+	if err != nil {
+		return nil, err
+	}
+	r.rules[key] = rule
+	return rule, nil
+}
+
+// GetProfileForResult gets the Profile or TailoredProfile that the scan of
+// the given ComplianceCheckResult evaluated
+func (r *RuleResolver) GetProfileForResult(res *unstructured.Unstructured) (ProfileHandler, error) {
+	scan, err := r.cache.GetControllerOf(res)
+	if err != nil {
+		return nil, err
+	}
+	spi, err := GetScanProfileID(scan)
+	if err != nil {
+		return nil, err
+	}
+	return r.getProfileForScan(scan, spi)
+}
+
+func (r *RuleResolver) getProfileForScan(scan *unstructured.Unstructured, spi ScanProfileID) (ProfileHandler, error) {
+	if ph, found := r.profiles[spi]; found {
+		return ph, nil
+	}
+
+	suite, err := r.cache.GetControllerOf(scan)
+	if err != nil {
+		return nil, goerrors.Wrapf(err, "cannot get a suite that owns scan %s", scan.GetName())
+	}
+	var ph ProfileHandler
+	if metav1.GetControllerOf(suite) == nil {
+		ph, err = r.findProfileByID(suite, spi)
+	} else {
+		var binding *unstructured.Unstructured
+		binding, err = r.cache.GetControllerOf(suite)
+		if err != nil {
+			return nil, goerrors.Wrapf(err, "cannot get a binding that owns suite %s", suite.GetName())
+		}
+		var profs []interface{}
+		profs, err = getProfilesFromBinding(binding)
+		if err != nil {
+			return nil, err
+		}
+		ph, err = r.findRelevantProfile(profs, binding, spi)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.profiles[spi] = ph
+	return ph, nil
+}
+
+// findProfileByID looks for the Profile or TailoredProfile with the given ID
+// among all the ones in the namespace. It's used for the scans of suites that
+// don't belong to a binding.
+func (r *RuleResolver) findProfileByID(suite *unstructured.Unstructured, spi ScanProfileID) (ProfileHandler, error) {
+	for _, resource := range []string{"profiles", "tailoredprofiles"} {
+		profs, err := r.cache.List(GVR(resource))
+		if err != nil {
+			return nil, err
+		}
+		for _, prof := range profs {
+			ph, err := NewProfileHandler(prof, suite.GetName(), r.cache)
+			if err != nil {
+				return nil, err
+			}
+			if ph.ProfileMatches(spi) {
+				return ph, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("Didn't find a profile with ID %s for suite %s", spi.id, suite.GetName())
+}
+
+func (r *RuleResolver) findRelevantProfile(profs []interface{}, binding *unstructured.Unstructured, spi ScanProfileID) (ProfileHandler, error) {
+	for _, rawProf := range profs {
+		profRef, ok := rawProf.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Error parsing profiles from ScanSettingBinding '%s' that this result belongs to", binding.GetName())
+		}
+		gvr := GetGVRFromProfileRef(profRef)
+		profname := profRef["name"].(string)
+		prof, err := r.cache.Get(gvr, profname)
+		if err != nil {
+			return nil, err
+		}
+		ph, err := NewProfileHandler(prof, binding.GetName(), r.cache)
+		if err != nil {
+			return nil, err
+		}
+		if ph.ProfileMatches(spi) {
+			return ph, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Didn't find relevant profile")
+}
+
+// Get a profile from a scansettingBinding object
+func getProfilesFromBinding(obj *unstructured.Unstructured) ([]interface{}, error) {
+	profs, found, err := unstructured.NestedSlice(obj.Object, "profiles")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get profiles of %s/%s of type %s: %s", obj.GetNamespace(), obj.GetName(), "ComplianceCheckResult", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%s/%s of type %s: has no 'profiles'", obj.GetNamespace(), obj.GetName(), "Profile")
+	}
+	return profs, nil
+}
+
+// GetControlsFromRule returns the controls per benchmark that the rule
+// addresses
+func GetControlsFromRule(rule *unstructured.Unstructured) map[string][]string {
+	annotations := rule.GetAnnotations()
+	if annotations == nil {
+		// non-fatal... but no controls to display
+		return nil
+	}
+
+	controls := map[string][]string{}
+	for key, value := range annotations {
+		if strings.HasPrefix(key, ControlAnnotationPrefix) {
+			benchmark := key[len(ControlAnnotationPrefix):]
+			controls[benchmark] = strings.Split(value, ";")
+		}
+	}
+	return controls
+}
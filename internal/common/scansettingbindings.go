@@ -0,0 +1,60 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// GetSuiteNamesFromBinding gets the names of the ComplianceSuites generated
+// from a ScanSettingBinding, that is, the suites the binding is the
+// controller of. If no suite references the binding, the suite named after
+// the binding is used, as that's the one the operator creates, as long as it
+// isn't owned by something else.
+func GetSuiteNamesFromBinding(kuser KubeClientUser, obj *unstructured.Unstructured) ([]string, error) {
+	suites, err := kuser.DynamicClient().Resource(GVR("compliancesuites")).Namespace(obj.GetNamespace()).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list suites of '%s/%s' of type %s: %s", obj.GetNamespace(), obj.GetName(), obj.GetKind(), err)
+	}
+
+	suiteNames := []string{}
+	for idx := range suites.Items {
+		suite := &suites.Items[idx]
+		if isControlledBy(suite, obj) {
+			suiteNames = append(suiteNames, suite.GetName())
+		}
+	}
+
+	if len(suiteNames) > 0 {
+		sort.Strings(suiteNames)
+		return suiteNames, nil
+	}
+
+	suite, err := kuser.DynamicClient().Resource(GVR("compliancesuites")).Namespace(obj.GetNamespace()).Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("'%s/%s' of type %s has no suites. Wait for the operator to create them", obj.GetNamespace(), obj.GetName(), obj.GetKind())
+	} else if err != nil {
+		return nil, fmt.Errorf("Unable to get suite of '%s/%s' of type %s: %s", obj.GetNamespace(), obj.GetName(), obj.GetKind(), err)
+	}
+	if ctrl := metav1.GetControllerOf(suite); ctrl != nil && (ctrl.Kind != obj.GetKind() || ctrl.Name != obj.GetName()) {
+		return nil, fmt.Errorf("'%s/%s' of type %s has no suites. The suite with the same name belongs to %s %s",
+			obj.GetNamespace(), obj.GetName(), obj.GetKind(), ctrl.Kind, ctrl.Name)
+	}
+	return []string{obj.GetName()}, nil
+}
+
+// isControlledBy checks whether the owner is the controller of the given object
+func isControlledBy(obj, owner *unstructured.Unstructured) bool {
+	ctrl := metav1.GetControllerOf(obj)
+	if ctrl == nil || ctrl.Kind != owner.GetKind() {
+		return false
+	}
+	if ctrl.UID != "" && owner.GetUID() != "" {
+		return ctrl.UID == owner.GetUID()
+	}
+	return ctrl.Name == owner.GetName()
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"os"
 	"strings"
 )
 
@@ -74,6 +75,27 @@ func ValidateManyObjectArgs(args []string) ([]ObjectReference, error) {
 	return out, nil
 }
 
+// ValidateObjectPairArgs parses the references to two objects, which may be
+// given as "<type> <name> <type> <name>" or "<type>/<name> <type>/<name>"
+func ValidateObjectPairArgs(args []string) (from, to ObjectReference, err error) {
+	var fromArgs, toArgs []string
+	switch len(args) {
+	case 2:
+		fromArgs, toArgs = args[:1], args[1:]
+	case 4:
+		fromArgs, toArgs = args[:2], args[2:]
+	default:
+		err = fmt.Errorf("You need to specify exactly two objects")
+		return
+	}
+
+	if from, err = ValidateObjectArgs(fromArgs); err != nil {
+		return
+	}
+	to, err = ValidateObjectArgs(toArgs)
+	return
+}
+
 func GetValidObjType(rawtype string) (ComplianceType, error) {
 	switch rawtype {
 	case "ScanSettingBindings", "ScanSettingBinding", "scansettingbindings", "scansettingbinding":
@@ -96,3 +118,18 @@ func GetValidObjType(rawtype string) (ComplianceType, error) {
 		return TypeUnknown, fmt.Errorf("Unknown object type: %s", rawtype)
 	}
 }
+
+// ValidateDirectory ensures that the given path exists and is a directory
+func ValidateDirectory(path string) error {
+	finfo, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("The directory at path '%s' doesn't exist", path)
+	}
+	if err != nil {
+		return err
+	}
+	if !finfo.IsDir() {
+		return fmt.Errorf("The path '%s' must be a directory", path)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package controls
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+type ComplianceSuiteHelper struct {
+	kuser    common.KubeClientUser
+	gvk      schema.GroupVersionResource
+	kind     string
+	name     string
+	coverage *Coverage
+	resolver *common.RuleResolver
+	genericclioptions.IOStreams
+}
+
+func NewComplianceSuiteHelper(kuser common.KubeClientUser, name string, coverage *Coverage, resolver *common.RuleResolver, streams genericclioptions.IOStreams) common.ObjectHelper {
+	return &ComplianceSuiteHelper{
+		kuser:     kuser,
+		name:      name,
+		kind:      "ComplianceSuite",
+		coverage:  coverage,
+		resolver:  resolver,
+		gvk:       common.GVR("compliancesuites"),
+		IOStreams: streams,
+	}
+}
+
+func (h *ComplianceSuiteHelper) Handle() error {
+	// Get target resource
+	res, err := h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), h.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Unable to get resource %s/%s of type %s: %s", h.kuser.GetNamespace(), h.name, h.kind, err)
+	}
+
+	// Get needed data
+	scanNames, err := common.GetScanNamesFromSuite(res)
+	if err != nil {
+		return err
+	}
+
+	for _, scanName := range scanNames {
+		results, err := common.GetCheckResultsFromScan(h.kuser, scanName)
+		if err != nil {
+			return err
+		}
+		for idx := range results {
+			if err := h.addResult(&results[idx]); err != nil {
+				return fmt.Errorf("Unable to process results from suite %s: %s", h.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (h *ComplianceSuiteHelper) addResult(res *unstructured.Unstructured) error {
+	status, found, err := unstructured.NestedString(res.Object, "status")
+	if err != nil {
+		return fmt.Errorf("Unable to get status of %s/%s of type %s: %s", res.GetNamespace(), res.GetName(), res.GetKind(), err)
+	}
+	if !found {
+		return fmt.Errorf("%s/%s of type %s: has no 'status'", res.GetNamespace(), res.GetName(), res.GetKind())
+	}
+	rule, err := h.resolver.GetRuleForResult(res)
+	if err != nil {
+		return fmt.Errorf("Unable to get the rule of result %s: %s", res.GetName(), err)
+	}
+	h.coverage.AddResult(rule, status)
+	return nil
+}
@@ -12,6 +12,11 @@ const AllBenchmarks = "all"
 type ControlsContext struct {
 	common.CommandContext
 	Benchmark string
+	Output    string
+
+	// coverage is only set when the controls are overlaid with the
+	// results of a scan
+	coverage *Coverage
 }
 
 func NewControlsContext(streams genericclioptions.IOStreams) *ControlsContext {
@@ -25,6 +30,12 @@ func NewControlsContext(streams genericclioptions.IOStreams) *ControlsContext {
 
 // Validate ensures that all required arguments and flag values are provided
 func (o *ControlsContext) Validate() error {
+	err := common.ValidateOutputFormat(o.Output, common.OutputFormatTable, common.OutputFormatJSON,
+		common.OutputFormatYAML, common.OutputFormatCSV, common.OutputFormatMarkdown)
+	if err != nil {
+		return err
+	}
+
 	objref, err := common.ValidateObjectArgs(o.Args)
 	if err != nil {
 		return err
@@ -32,7 +43,15 @@ func (o *ControlsContext) Validate() error {
 
 	switch objref.Type {
 	case common.Profile:
-		o.Helper = NewProfileHelper(o.Kuser, objref.Name, o.IOStreams, o.Benchmark)
+		o.Helper = NewProfileHelper(o.Kuser, objref.Name, o.IOStreams, o.Benchmark, o.Output)
+	case common.TailoredProfile:
+		o.Helper = NewTailoredProfileHelper(o.Kuser, objref.Name, o.IOStreams, o.Benchmark, o.Output)
+	case common.ScanSettingBinding:
+		o.coverage = NewCoverage(o.Benchmark)
+		o.Helper = NewScanSettingBindingHelper(o.Kuser, objref.Name, o.coverage, common.NewRuleResolver(o.Kuser), o.IOStreams)
+	case common.ComplianceSuite:
+		o.coverage = NewCoverage(o.Benchmark)
+		o.Helper = NewComplianceSuiteHelper(o.Kuser, objref.Name, o.coverage, common.NewRuleResolver(o.Kuser), o.IOStreams)
 	default:
 		return fmt.Errorf("Invalid object type for this command")
 	}
@@ -40,5 +59,11 @@ func (o *ControlsContext) Validate() error {
 }
 
 func (o *ControlsContext) Run() error {
-	return o.Helper.Handle()
+	if err := o.Helper.Handle(); err != nil {
+		return err
+	}
+	if o.coverage != nil {
+		return o.coverage.Report().print(o.Out, o.Output)
+	}
+	return nil
 }
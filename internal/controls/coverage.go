@@ -0,0 +1,240 @@
+package controls
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+// The status of a control, which is derived from the status of the rules
+// that address it
+const (
+	// ControlStatusPass means that all the rules passed
+	ControlStatusPass = "PASS"
+	// ControlStatusFail means that all the rules failed
+	ControlStatusFail = "FAIL"
+	// ControlStatusPartial means that some of the rules failed
+	ControlStatusPartial = "PARTIAL"
+	// ControlStatusManual means that no rule failed, but some need to be
+	// checked manually
+	ControlStatusManual = "MANUAL"
+	// ControlStatusNotApplicable means that none of the rules applied
+	ControlStatusNotApplicable = "NOT-APPLICABLE"
+)
+
+// statusRank orders the check result statuses from worst to best. It's used
+// to pick the status of rules that were checked by several scans (e.g. on
+// each node role).
+var statusRank = map[string]int{
+	"FAIL":           0,
+	"INCONSISTENT":   1,
+	"ERROR":          2,
+	"MANUAL":         3,
+	"INFO":           4,
+	"PASS":           5,
+	"NOT-APPLICABLE": 6,
+	"SKIP":           7,
+}
+
+// Coverage gathers the status of the rules that address each control
+type Coverage struct {
+	benchmark string
+	mapping   BenchMarkCtrlsMapping
+	// worst status of each rule across all the scans
+	ruleStatuses map[string]string
+}
+
+func NewCoverage(benchmark string) *Coverage {
+	return &Coverage{
+		benchmark:    benchmark,
+		mapping:      BenchMarkCtrlsMapping{},
+		ruleStatuses: map[string]string{},
+	}
+}
+
+// AddResult records the status of a check result of the given rule
+func (c *Coverage) AddResult(rule *unstructured.Unstructured, status string) {
+	name := rule.GetName()
+	if current, found := c.ruleStatuses[name]; found {
+		if rankStatus(status) < rankStatus(current) {
+			c.ruleStatuses[name] = status
+		}
+		// The controls of the rule were already recorded
+		return
+	}
+	c.ruleStatuses[name] = status
+
+	for benchmark, ctrls := range common.GetControlsFromRule(rule) {
+		if c.benchmark != AllBenchmarks && c.benchmark != benchmark {
+			continue
+		}
+		if c.mapping[benchmark] == nil {
+			c.mapping[benchmark] = CtrlRulesMapping{}
+		}
+		for _, ctrl := range ctrls {
+			if ctrl == "" {
+				continue
+			}
+			c.mapping[benchmark][ctrl] = append(c.mapping[benchmark][ctrl], name)
+		}
+	}
+}
+
+func rankStatus(status string) int {
+	if rank, found := statusRank[status]; found {
+		return rank
+	}
+	// Unknown statuses are treated as errors
+	return statusRank["ERROR"]
+}
+
+// CoverageReport is the status of the controls of each framework
+type CoverageReport struct {
+	Frameworks []FrameworkCoverage `json:"frameworks"`
+}
+
+// FrameworkCoverage is the status of the controls of a framework
+type FrameworkCoverage struct {
+	Name     string            `json:"name"`
+	Controls []ControlCoverage `json:"controls"`
+	// Satisfied is the number of controls whose rules all passed
+	Satisfied int `json:"satisfied"`
+	// Applicable is the number of controls with at least one applicable
+	// rule
+	Applicable int `json:"applicable"`
+	// Percentage of the applicable controls that are satisfied
+	Percentage float64 `json:"percentage"`
+}
+
+// ControlCoverage is the status of a control and of the rules that address
+// it
+type ControlCoverage struct {
+	Name   string       `json:"name"`
+	Status string       `json:"status"`
+	Rules  []RuleStatus `json:"rules"`
+}
+
+// RuleStatus is the worst status of a rule across all the scans
+type RuleStatus struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Report computes the status of each control and framework
+func (c *Coverage) Report() *CoverageReport {
+	report := &CoverageReport{Frameworks: []FrameworkCoverage{}}
+	benchmarks := make([]string, 0, len(c.mapping))
+	for benchmark := range c.mapping {
+		benchmarks = append(benchmarks, benchmark)
+	}
+	sort.Strings(benchmarks)
+
+	for _, benchmark := range benchmarks {
+		fw := FrameworkCoverage{Name: benchmark}
+		ctrls := c.mapping[benchmark]
+		ctrlNames := make([]string, 0, len(ctrls))
+		for ctrl := range ctrls {
+			ctrlNames = append(ctrlNames, ctrl)
+		}
+		sort.Strings(ctrlNames)
+		for _, ctrl := range ctrlNames {
+			rules := append(RulesList{}, ctrls[ctrl]...)
+			sort.Strings(rules)
+			cc := ControlCoverage{Name: ctrl}
+			for _, rule := range rules {
+				cc.Rules = append(cc.Rules, RuleStatus{rule, c.ruleStatuses[rule]})
+			}
+			cc.Status = controlStatus(cc.Rules)
+			switch cc.Status {
+			case ControlStatusPass:
+				fw.Satisfied++
+				fw.Applicable++
+			case ControlStatusNotApplicable:
+			default:
+				fw.Applicable++
+			}
+			fw.Controls = append(fw.Controls, cc)
+		}
+		if fw.Applicable > 0 {
+			fw.Percentage = float64(fw.Satisfied) * 100 / float64(fw.Applicable)
+		}
+		report.Frameworks = append(report.Frameworks, fw)
+	}
+	return report
+}
+
+// controlStatus derives the status of a control from its rules
+func controlStatus(rules []RuleStatus) string {
+	var failed, passed, manual int
+	for _, rule := range rules {
+		switch rankStatus(rule.Status) {
+		case statusRank["FAIL"], statusRank["INCONSISTENT"], statusRank["ERROR"]:
+			failed++
+		case statusRank["MANUAL"]:
+			manual++
+		case statusRank["INFO"], statusRank["PASS"]:
+			passed++
+		}
+	}
+
+	switch {
+	case failed > 0 && passed+manual > 0:
+		return ControlStatusPartial
+	case failed > 0:
+		return ControlStatusFail
+	case manual > 0:
+		return ControlStatusManual
+	case passed > 0:
+		return ControlStatusPass
+	}
+	return ControlStatusNotApplicable
+}
+
+// rows flattens the report into a row per rule of each control
+func (r *CoverageReport) rows() [][]string {
+	rows := [][]string{}
+	for _, fw := range r.Frameworks {
+		for _, ctrl := range fw.Controls {
+			for _, rule := range ctrl.Rules {
+				rows = append(rows, []string{fw.Name, ctrl.Name, ctrl.Status, rule.Name, rule.Status})
+			}
+		}
+	}
+	return rows
+}
+
+func (r *CoverageReport) print(w io.Writer, output string) error {
+	switch output {
+	case common.OutputFormatTable:
+		r.render(w)
+		return nil
+	case common.OutputFormatJSON, common.OutputFormatYAML:
+		return common.PrintStructured(w, output, r)
+	default:
+		return common.PrintTabular(w, output, []string{"Framework", "Control", "Status", "Rule", "Rule Status"}, r.rows())
+	}
+}
+
+func (r *CoverageReport) render(w io.Writer) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Framework", "Controls", "Status", "Rules"})
+	table.SetAutoMergeCellsByColumnIndex([]int{0, 1})
+	table.SetRowLine(true)
+	for _, row := range r.rows() {
+		table.Append([]string{row[0], row[1], row[2], fmt.Sprintf("%s (%s)", row[3], row[4])})
+	}
+	table.Render()
+
+	fmt.Fprintln(w)
+	table = tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Framework", "Satisfied", "Applicable", "Compliance"})
+	for _, fw := range r.Frameworks {
+		table.Append([]string{fw.Name, fmt.Sprint(fw.Satisfied), fmt.Sprint(fw.Applicable), fmt.Sprintf("%.1f%%", fw.Percentage)})
+	}
+	table.Render()
+}
@@ -0,0 +1,207 @@
+package controls
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+// LookupContext finds what addresses a control of a benchmark: the rules,
+// the profiles that check them and their current results
+type LookupContext struct {
+	common.CommandContext
+	Benchmark string
+	Control   string
+	Output    string
+
+	cache *common.ObjectCache
+}
+
+// LookupReport is the result of looking up a control
+type LookupReport struct {
+	Benchmark string       `json:"benchmark"`
+	Control   string       `json:"control"`
+	Rules     []RuleLookup `json:"rules"`
+}
+
+// RuleLookup is a rule that addresses the looked up control
+type RuleLookup struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	// Controls that matched the lookup. These may be enhancements of the
+	// looked up control (e.g. AC-6(1) for AC-6)
+	Controls []string      `json:"controls"`
+	Profiles []string      `json:"profiles"`
+	Results  []CheckResult `json:"results"`
+}
+
+// CheckResult is the current result of a rule
+type CheckResult struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+func NewLookupContext(streams genericclioptions.IOStreams) *LookupContext {
+	return &LookupContext{
+		CommandContext: common.CommandContext{
+			ConfigFlags: genericclioptions.NewConfigFlags(true),
+			IOStreams:   streams,
+		},
+	}
+}
+
+// Validate ensures that all required arguments and flag values are provided
+func (o *LookupContext) Validate() error {
+	if len(o.Args) > 0 {
+		return fmt.Errorf("unkown argument(s): %s", o.Args)
+	}
+	if o.Benchmark == "" || o.Benchmark == AllBenchmarks {
+		return fmt.Errorf("You need to specify the benchmark of the control")
+	}
+	if o.Control == "" {
+		return fmt.Errorf("You need to specify the control to look up")
+	}
+	return common.ValidateOutputFormat(o.Output, common.OutputFormatTable, common.OutputFormatJSON, common.OutputFormatYAML)
+}
+
+func (o *LookupContext) Run() error {
+	report := &LookupReport{
+		Benchmark: o.Benchmark,
+		Control:   o.Control,
+		Rules:     []RuleLookup{},
+	}
+
+	// The cache lists the objects in pages, and serves the rules to the
+	// profile handlers afterwards
+	o.cache = common.NewObjectCache(o.Kuser)
+	rules, err := o.cache.List(common.GVR("rules"))
+	if err != nil {
+		return err
+	}
+	// Rules are indexed by their reference in the content, which is what
+	// the check results point to. Rules of different bundles may share it.
+	ruleRefs := map[string][]int{}
+	for _, rule := range rules {
+		matched := o.matchingControls(rule)
+		if len(matched) == 0 {
+			continue
+		}
+		title, _, _ := unstructured.NestedString(rule.Object, "title")
+		if ref := rule.GetAnnotations()[common.RuleAnnotationKey]; ref != "" {
+			ruleRefs[ref] = append(ruleRefs[ref], len(report.Rules))
+		}
+		report.Rules = append(report.Rules, RuleLookup{
+			Name:     rule.GetName(),
+			Title:    title,
+			Controls: matched,
+			Profiles: []string{},
+			Results:  []CheckResult{},
+		})
+	}
+	if len(report.Rules) == 0 {
+		return fmt.Errorf("No rules address control %s of benchmark %s", o.Control, o.Benchmark)
+	}
+
+	if err := o.addProfiles(report); err != nil {
+		return err
+	}
+	if err := o.addResults(report, ruleRefs); err != nil {
+		return err
+	}
+
+	sort.Slice(report.Rules, func(i, j int) bool { return report.Rules[i].Name < report.Rules[j].Name })
+
+	if o.Output != common.OutputFormatTable {
+		return common.PrintStructured(o.Out, o.Output, report)
+	}
+	report.render(o.Out)
+	return nil
+}
+
+// matchingControls gets the controls of the looked up benchmark that the rule
+// addresses and that are either the looked up control or an enhancement of
+// it
+func (o *LookupContext) matchingControls(rule *unstructured.Unstructured) []string {
+	matched := []string{}
+	for _, ctrl := range common.GetControlsFromRule(rule)[o.Benchmark] {
+		if ctrl == o.Control || strings.HasPrefix(ctrl, o.Control+"(") {
+			matched = append(matched, ctrl)
+		}
+	}
+	return matched
+}
+
+// addProfiles records the Profiles and TailoredProfiles that check each of
+// the rules
+func (o *LookupContext) addProfiles(report *LookupReport) error {
+	ruleIndexes := map[string]int{}
+	for idx := range report.Rules {
+		ruleIndexes[report.Rules[idx].Name] = idx
+	}
+
+	for _, resource := range []string{"profiles", "tailoredprofiles"} {
+		profiles, err := o.cache.List(common.GVR(resource))
+		if err != nil {
+			return err
+		}
+		for _, prof := range profiles {
+			ph, err := common.NewProfileHandler(prof, prof.GetName(), o.cache)
+			if err != nil {
+				return err
+			}
+			profRules, err := ph.GetRules()
+			if err != nil {
+				fmt.Fprintf(o.ErrOut, "WARNING: Skipping %s %s: %s\n", prof.GetKind(), prof.GetName(), err)
+				continue
+			}
+			for _, rule := range profRules {
+				if ridx, found := ruleIndexes[rule]; found {
+					report.Rules[ridx].Profiles = append(report.Rules[ridx].Profiles, prof.GetName())
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addResults records the current check results of each of the rules
+func (o *LookupContext) addResults(report *LookupReport, ruleRefs map[string][]int) error {
+	results, err := o.cache.List(common.GVR("compliancecheckresults"))
+	if err != nil {
+		return err
+	}
+	for _, res := range results {
+		status, _, _ := unstructured.NestedString(res.Object, "status")
+		for _, ridx := range ruleRefs[res.GetAnnotations()[common.RuleAnnotationKey]] {
+			report.Rules[ridx].Results = append(report.Rules[ridx].Results, CheckResult{res.GetName(), status})
+		}
+	}
+	return nil
+}
+
+func (r *LookupReport) render(w io.Writer) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Rule", "Controls", "Profiles", "Results"})
+	table.SetAutoWrapText(false)
+	table.SetRowLine(true)
+	for _, rule := range r.Rules {
+		results := make([]string, 0, len(rule.Results))
+		for _, res := range rule.Results {
+			results = append(results, fmt.Sprintf("%s: %s", res.Name, res.Status))
+		}
+		table.Append([]string{
+			rule.Name,
+			strings.Join(rule.Controls, "\n"),
+			strings.Join(rule.Profiles, "\n"),
+			strings.Join(results, "\n"),
+		})
+	}
+	table.Render()
+}
@@ -30,9 +30,10 @@ type ProfileHelper struct {
 	name    string
 	genericclioptions.IOStreams
 	benchmark string
+	output    string
 }
 
-func NewProfileHelper(kuser common.KubeClientUser, name string, streams genericclioptions.IOStreams, b string) common.ObjectHelper {
+func NewProfileHelper(kuser common.KubeClientUser, name string, streams genericclioptions.IOStreams, b, output string) common.ObjectHelper {
 	return &ProfileHelper{
 		kuser: kuser,
 		name:  name,
@@ -49,9 +50,19 @@ func NewProfileHelper(kuser common.KubeClientUser, name string, streams genericc
 		},
 		IOStreams: streams,
 		benchmark: b,
+		output:    output,
 	}
 }
 
+// NewTailoredProfileHelper creates a helper for the rules that a
+// TailoredProfile effectively checks
+func NewTailoredProfileHelper(kuser common.KubeClientUser, name string, streams genericclioptions.IOStreams, b, output string) common.ObjectHelper {
+	h := NewProfileHelper(kuser, name, streams, b, output).(*ProfileHelper)
+	h.kind = "TailoredProfile"
+	h.gvk = common.GVR("tailoredprofiles")
+	return h
+}
+
 func (h *ProfileHelper) Handle() error {
 	results := BenchMarkCtrlsMapping{}
 
@@ -60,12 +71,22 @@ func (h *ProfileHelper) Handle() error {
 		return err
 	}
 
-	rules, err := common.GetRulesFromProfile(p)
+	cache := common.NewObjectCache(h.kuser)
+	ph, err := common.NewProfileHandler(p, h.name, cache)
+	if err != nil {
+		return err
+	}
+	rules, err := ph.GetRules()
 	if err != nil {
 		return err
 	}
+	// TailoredProfiles aren't labeled with their bundle, so all rules are
+	// listed for them
+	if err := cache.PrefetchRules(p.GetLabels()[common.ProfileBundleLabel]); err != nil {
+		return err
+	}
 	for _, rulename := range rules {
-		r, err := h.kuser.DynamicClient().Resource(h.rulegvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), rulename, metav1.GetOptions{})
+		r, err := cache.Get(h.rulegvk, rulename)
 		if err != nil {
 			return err
 		}
@@ -83,8 +104,20 @@ func (h *ProfileHelper) Handle() error {
 		}
 	}
 
-	h.render(results)
-	return nil
+	return h.print(results)
+}
+
+func (h *ProfileHelper) print(res BenchMarkCtrlsMapping) error {
+	res.sort()
+	switch h.output {
+	case common.OutputFormatTable:
+		h.render(res)
+		return nil
+	case common.OutputFormatJSON, common.OutputFormatYAML:
+		return common.PrintStructured(h.Out, h.output, res)
+	default:
+		return common.PrintTabular(h.Out, h.output, []string{"Framework", "Control", "Rule"}, res.rows())
+	}
 }
 
 func (h *ProfileHelper) render(res BenchMarkCtrlsMapping) {
@@ -92,25 +125,43 @@ func (h *ProfileHelper) render(res BenchMarkCtrlsMapping) {
 	table.SetHeader([]string{"Framework", "Controls", "Rules"})
 	table.SetAutoMergeCells(true)
 	table.SetRowLine(true)
+	table.AppendBulk(res.rows())
+	table.Render()
+}
 
-	for benchmark, bmap := range res {
-		if !h.benchmarkMatches(benchmark) {
-			continue
+// sort sorts the rules of every control, so the output is stable
+func (res BenchMarkCtrlsMapping) sort() {
+	for _, bmap := range res {
+		for _, rules := range bmap {
+			sort.Strings(rules)
 		}
+	}
+}
+
+// rows flattens the mapping into framework, control and rule rows, sorted
+// by framework and control
+func (res BenchMarkCtrlsMapping) rows() [][]string {
+	benchmarks := make([]string, 0, len(res))
+	for benchmark := range res {
+		benchmarks = append(benchmarks, benchmark)
+	}
+	sort.Strings(benchmarks)
+
+	rows := [][]string{}
+	for _, benchmark := range benchmarks {
+		bmap := res[benchmark]
 		controls := make([]string, 0, len(bmap))
 		for k := range bmap {
 			controls = append(controls, k)
 		}
 		sort.Strings(controls)
 		for _, control := range controls {
-			rules := bmap[control]
-			sort.Strings(rules)
-			for _, rule := range rules {
-				table.Append([]string{benchmark, control, rule})
+			for _, rule := range bmap[control] {
+				rows = append(rows, []string{benchmark, control, rule})
 			}
 		}
 	}
-	table.Render()
+	return rows
 }
 
 func (h *ProfileHelper) insertControlEntries(res BenchMarkCtrlsMapping, benchmark, rawcontrols, rulename string) BenchMarkCtrlsMapping {
@@ -127,7 +178,7 @@ func (h *ProfileHelper) insertControlEntries(res BenchMarkCtrlsMapping, benchmar
 	controls := strings.Split(rawcontrols, ";")
 	for _, incomingctrl := range controls {
 		if incomingctrl == "" {
-			fmt.Printf("WARNING: empty control in %s", rawcontrols)
+			fmt.Fprintf(h.ErrOut, "Warning: empty control in %s\n", rawcontrols)
 			continue
 		}
 		rules, ctrlfound := benchMap[incomingctrl]
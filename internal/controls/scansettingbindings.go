@@ -0,0 +1,55 @@
+package controls
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+type ScanSettingBindingHelper struct {
+	kuser    common.KubeClientUser
+	gvk      schema.GroupVersionResource
+	kind     string
+	name     string
+	coverage *Coverage
+	resolver *common.RuleResolver
+	genericclioptions.IOStreams
+}
+
+func NewScanSettingBindingHelper(kuser common.KubeClientUser, name string, coverage *Coverage, resolver *common.RuleResolver, streams genericclioptions.IOStreams) common.ObjectHelper {
+	return &ScanSettingBindingHelper{
+		kuser:     kuser,
+		name:      name,
+		kind:      "ScanSettingBinding",
+		coverage:  coverage,
+		resolver:  resolver,
+		gvk:       common.GVR("scansettingbindings"),
+		IOStreams: streams,
+	}
+}
+
+func (h *ScanSettingBindingHelper) Handle() error {
+	// Get target resource
+	res, err := h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), h.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Unable to get resource %s/%s of type %s: %s", h.kuser.GetNamespace(), h.name, h.kind, err)
+	}
+
+	suiteNames, err := common.GetSuiteNamesFromBinding(h.kuser, res)
+	if err != nil {
+		return err
+	}
+
+	for _, suiteName := range suiteNames {
+		helper := NewComplianceSuiteHelper(h.kuser, suiteName, h.coverage, h.resolver, h.IOStreams)
+		if err := helper.Handle(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package diff
+
+import (
+	"fmt"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+type DiffContext struct {
+	common.CommandContext
+	Output string
+}
+
+func NewDiffContext(streams genericclioptions.IOStreams) *DiffContext {
+	return &DiffContext{
+		CommandContext: common.CommandContext{
+			ConfigFlags: genericclioptions.NewConfigFlags(true),
+			IOStreams:   streams,
+		},
+	}
+}
+
+// Validate ensures that all required arguments and flag values are provided
+func (o *DiffContext) Validate() error {
+	if err := common.ValidateOutputFormat(o.Output, common.OutputFormatTable, common.OutputFormatJSON, common.OutputFormatYAML); err != nil {
+		return err
+	}
+
+	from, to, err := common.ValidateObjectPairArgs(o.Args)
+	if err != nil {
+		return err
+	}
+	for _, ref := range []common.ObjectReference{from, to} {
+		if ref.Type != common.Profile && ref.Type != common.TailoredProfile {
+			return fmt.Errorf("Invalid object type for this command")
+		}
+	}
+
+	o.Helper = NewProfileDiffHelper(o.Kuser, from, to, o.Output, o.IOStreams)
+	return nil
+}
+
+func (o *DiffContext) Run() error {
+	return o.Helper.Handle()
+}
@@ -0,0 +1,321 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+// ProfileDiffSchemaVersion is the version of the structured profile diff
+const ProfileDiffSchemaVersion = "v1"
+
+// ProfileDiff holds the differences between the effective contents of two
+// profiles
+type ProfileDiff struct {
+	SchemaVersion    string           `json:"schemaVersion"`
+	From             string           `json:"from"`
+	To               string           `json:"to"`
+	AddedRules       []string         `json:"addedRules"`
+	RemovedRules     []string         `json:"removedRules"`
+	AddedVariables   []Variable       `json:"addedVariables"`
+	RemovedVariables []Variable       `json:"removedVariables"`
+	ChangedVariables []VariableChange `json:"changedVariables"`
+	ChangedControls  []ControlChange  `json:"changedControls"`
+}
+
+// Variable is a variable used by a profile
+type Variable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// VariableChange is a variable whose value differs between the profiles
+type VariableChange struct {
+	Name string `json:"name"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ControlChange lists the controls of a benchmark that were added to or
+// removed from a rule that both profiles check
+type ControlChange struct {
+	Rule      string   `json:"rule"`
+	Benchmark string   `json:"benchmark"`
+	Added     []string `json:"added"`
+	Removed   []string `json:"removed"`
+}
+
+// IsEmpty tells whether the profiles have the same contents
+func (d *ProfileDiff) IsEmpty() bool {
+	return len(d.AddedRules)+len(d.RemovedRules)+len(d.AddedVariables)+len(d.RemovedVariables)+
+		len(d.ChangedVariables)+len(d.ChangedControls) == 0
+}
+
+// profileContents is what a profile effectively checks
+type profileContents struct {
+	// rules indexed by their reference in the content. This allows
+	// comparing profiles of different bundles, whose rules are named
+	// after the bundle.
+	rules map[string]*unstructured.Unstructured
+	// variables indexed by their name in the content, for the same reason
+	vars map[string]Variable
+}
+
+type ProfileDiffHelper struct {
+	kuser  common.KubeClientUser
+	from   common.ObjectReference
+	to     common.ObjectReference
+	output string
+	cache  *common.ObjectCache
+	genericclioptions.IOStreams
+}
+
+func NewProfileDiffHelper(kuser common.KubeClientUser, from, to common.ObjectReference, output string, streams genericclioptions.IOStreams) common.ObjectHelper {
+	return &ProfileDiffHelper{
+		kuser:     kuser,
+		from:      from,
+		to:        to,
+		output:    output,
+		cache:     common.NewObjectCache(kuser),
+		IOStreams: streams,
+	}
+}
+
+func (h *ProfileDiffHelper) Handle() error {
+	from, err := h.getContents(h.from)
+	if err != nil {
+		return err
+	}
+	to, err := h.getContents(h.to)
+	if err != nil {
+		return err
+	}
+
+	d := diffProfiles(from, to)
+	d.From = describeRef(h.from)
+	d.To = describeRef(h.to)
+
+	if h.output != common.OutputFormatTable {
+		return common.PrintStructured(h.Out, h.output, d)
+	}
+	d.render(h.Out)
+	return nil
+}
+
+func describeRef(ref common.ObjectReference) string {
+	if ref.Type == common.TailoredProfile {
+		return "tailoredprofile/" + ref.Name
+	}
+	return "profile/" + ref.Name
+}
+
+func (h *ProfileDiffHelper) getContents(ref common.ObjectReference) (*profileContents, error) {
+	resource := "profiles"
+	if ref.Type == common.TailoredProfile {
+		resource = "tailoredprofiles"
+	}
+	obj, err := h.cache.Get(common.GVR(resource), ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get %s: %s", describeRef(ref), err)
+	}
+
+	ph, err := common.NewProfileHandler(obj, ref.Name, h.cache)
+	if err != nil {
+		return nil, err
+	}
+	contents := &profileContents{
+		rules: map[string]*unstructured.Unstructured{},
+		vars:  map[string]Variable{},
+	}
+	vars, err := ph.GetVariables()
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range vars {
+		key, err := h.getVariableKey(name)
+		if err != nil {
+			return nil, err
+		}
+		contents.vars[key] = Variable{name, value}
+	}
+	rules, err := ph.GetRules()
+	if err != nil {
+		return nil, err
+	}
+
+	// TailoredProfiles aren't labeled with their bundle, so all rules are
+	// listed for them
+	if err := h.cache.PrefetchRules(obj.GetLabels()[common.ProfileBundleLabel]); err != nil {
+		return nil, err
+	}
+	for _, name := range rules {
+		rule, err := h.cache.Get(common.GVR("rules"), name)
+		if err != nil {
+			return nil, err
+		}
+		key := rule.GetAnnotations()[common.RuleAnnotationKey]
+		if key == "" {
+			key = name
+		}
+		contents.rules[key] = rule
+	}
+	return contents, nil
+}
+
+// getVariableKey gets the name of a variable in the content, which is the
+// name of the object without the prefix of its bundle
+func (h *ProfileDiffHelper) getVariableKey(name string) (string, error) {
+	variable, err := h.cache.Get(common.GVR("variables"), name)
+	if err != nil {
+		return "", fmt.Errorf("Unable to get variable %s: %s", name, err)
+	}
+	bundle := variable.GetLabels()[common.ProfileBundleLabel]
+	if bundle == "" {
+		return name, nil
+	}
+	return strings.TrimPrefix(name, bundle+"-"), nil
+}
+
+func diffProfiles(from, to *profileContents) *ProfileDiff {
+	d := &ProfileDiff{
+		SchemaVersion:    ProfileDiffSchemaVersion,
+		AddedRules:       []string{},
+		RemovedRules:     []string{},
+		AddedVariables:   []Variable{},
+		RemovedVariables: []Variable{},
+		ChangedVariables: []VariableChange{},
+		ChangedControls:  []ControlChange{},
+	}
+
+	for key, rule := range to.rules {
+		fromRule, found := from.rules[key]
+		if !found {
+			d.AddedRules = append(d.AddedRules, rule.GetName())
+			continue
+		}
+		d.ChangedControls = append(d.ChangedControls, diffControls(fromRule, rule)...)
+	}
+	for key, rule := range from.rules {
+		if _, found := to.rules[key]; !found {
+			d.RemovedRules = append(d.RemovedRules, rule.GetName())
+		}
+	}
+
+	for key, variable := range to.vars {
+		fromVariable, found := from.vars[key]
+		if !found {
+			d.AddedVariables = append(d.AddedVariables, variable)
+		} else if fromVariable.Value != variable.Value {
+			d.ChangedVariables = append(d.ChangedVariables, VariableChange{variable.Name, fromVariable.Value, variable.Value})
+		}
+	}
+	for key, variable := range from.vars {
+		if _, found := to.vars[key]; !found {
+			d.RemovedVariables = append(d.RemovedVariables, variable)
+		}
+	}
+
+	sort.Strings(d.AddedRules)
+	sort.Strings(d.RemovedRules)
+	sort.Slice(d.AddedVariables, func(i, j int) bool { return d.AddedVariables[i].Name < d.AddedVariables[j].Name })
+	sort.Slice(d.RemovedVariables, func(i, j int) bool { return d.RemovedVariables[i].Name < d.RemovedVariables[j].Name })
+	sort.Slice(d.ChangedVariables, func(i, j int) bool { return d.ChangedVariables[i].Name < d.ChangedVariables[j].Name })
+	sort.Slice(d.ChangedControls, func(i, j int) bool {
+		a, b := d.ChangedControls[i], d.ChangedControls[j]
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Benchmark < b.Benchmark
+	})
+	return d
+}
+
+// diffControls compares the control annotations of two versions of a rule
+func diffControls(from, to *unstructured.Unstructured) []ControlChange {
+	fromCtrls := common.GetControlsFromRule(from)
+	toCtrls := common.GetControlsFromRule(to)
+
+	benchmarks := map[string]bool{}
+	for benchmark := range fromCtrls {
+		benchmarks[benchmark] = true
+	}
+	for benchmark := range toCtrls {
+		benchmarks[benchmark] = true
+	}
+
+	changes := []ControlChange{}
+	for benchmark := range benchmarks {
+		added := subtract(toCtrls[benchmark], fromCtrls[benchmark])
+		removed := subtract(fromCtrls[benchmark], toCtrls[benchmark])
+		if len(added) == 0 && len(removed) == 0 {
+			continue
+		}
+		changes = append(changes, ControlChange{
+			Rule:      to.GetName(),
+			Benchmark: benchmark,
+			Added:     added,
+			Removed:   removed,
+		})
+	}
+	return changes
+}
+
+// subtract returns the sorted items of a that aren't in b
+func subtract(a, b []string) []string {
+	inB := map[string]bool{}
+	for _, item := range b {
+		inB[item] = true
+	}
+	out := []string{}
+	for _, item := range a {
+		if !inB[item] && item != "" {
+			out = append(out, item)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (d *ProfileDiff) render(w io.Writer) {
+	if d.IsEmpty() {
+		fmt.Fprintf(w, "No differences between %s and %s\n", d.From, d.To)
+		return
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Change", "Name", "Details"})
+	table.SetAutoWrapText(false)
+	for _, rule := range d.AddedRules {
+		table.Append([]string{"+ rule", rule, ""})
+	}
+	for _, rule := range d.RemovedRules {
+		table.Append([]string{"- rule", rule, ""})
+	}
+	for _, v := range d.AddedVariables {
+		table.Append([]string{"+ variable", v.Name, v.Value})
+	}
+	for _, v := range d.RemovedVariables {
+		table.Append([]string{"- variable", v.Name, v.Value})
+	}
+	for _, v := range d.ChangedVariables {
+		table.Append([]string{"~ variable", v.Name, fmt.Sprintf("%s -> %s", v.From, v.To)})
+	}
+	for _, c := range d.ChangedControls {
+		details := []string{}
+		for _, ctrl := range c.Added {
+			details = append(details, "+"+ctrl)
+		}
+		for _, ctrl := range c.Removed {
+			details = append(details, "-"+ctrl)
+		}
+		table.Append([]string{"~ controls", c.Rule, fmt.Sprintf("%s: %s", c.Benchmark, strings.Join(details, " "))})
+	}
+	table.Render()
+}
@@ -0,0 +1,90 @@
+package diffresults
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+type ComplianceSuiteHelper struct {
+	kuser  common.KubeClientUser
+	gvk    schema.GroupVersionResource
+	kind   string
+	name   string
+	checks checkSet
+	genericclioptions.IOStreams
+}
+
+func NewComplianceSuiteHelper(kuser common.KubeClientUser, name string, checks checkSet, streams genericclioptions.IOStreams) common.ObjectHelper {
+	return &ComplianceSuiteHelper{
+		kuser:     kuser,
+		name:      name,
+		kind:      "ComplianceSuite",
+		checks:    checks,
+		gvk:       common.GVR("compliancesuites"),
+		IOStreams: streams,
+	}
+}
+
+// Handle records the checks of all the scans of the suite. Scans are
+// generated per profile and node role, so the checks are identified by the
+// rule and the role they were done on, which allows comparing the results of
+// suites of different bindings.
+func (h *ComplianceSuiteHelper) Handle() error {
+	// Get target resource
+	res, err := h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), h.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Unable to get resource %s/%s of type %s: %s", h.kuser.GetNamespace(), h.name, h.kind, err)
+	}
+
+	// Get needed data
+	scanNames, err := common.GetScanNamesFromSuite(res)
+	if err != nil {
+		return err
+	}
+
+	for _, scanName := range scanNames {
+		scan, err := h.kuser.DynamicClient().Resource(common.GVR("compliancescans")).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), scanName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("Unable to get resource %s/%s of type %s: %s", h.kuser.GetNamespace(), scanName, "ComplianceScan", err)
+		}
+		scope := getScanScope(scan)
+
+		results, err := common.GetCheckResultsFromScan(h.kuser, scanName)
+		if err != nil {
+			return err
+		}
+		for idx := range results {
+			ccr := &results[idx]
+			rule := ccr.GetAnnotations()[common.RuleAnnotationKey]
+			if rule == "" {
+				return fmt.Errorf("Malformed result %s. It doesn't contain a rule reference.", ccr.GetName())
+			}
+			status, _, _ := unstructured.NestedString(ccr.Object, "status")
+			h.checks.add(rule, scope, status, ccr.GetName())
+		}
+	}
+	return nil
+}
+
+// getScanScope gets what a scan checks: the platform or the nodes of a role
+func getScanScope(scan *unstructured.Unstructured) string {
+	scanType, _, _ := unstructured.NestedString(scan.Object, "spec", "scanType")
+	if strings.EqualFold(scanType, "Platform") {
+		return "platform"
+	}
+	selector, _, _ := unstructured.NestedStringMap(scan.Object, "spec", "nodeSelector")
+	for label := range selector {
+		if strings.HasPrefix(label, common.NodeRoleLabelPrefix) {
+			return strings.TrimPrefix(label, common.NodeRoleLabelPrefix)
+		}
+	}
+	return "node"
+}
@@ -0,0 +1,113 @@
+package diffresults
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+	"github.com/openshift/oc-compliance/internal/rawresults"
+)
+
+type DiffResultsContext struct {
+	common.CommandContext
+	Output string
+	// Raw makes the arguments directories with fetched raw results
+	Raw bool
+
+	from     checkSet
+	to       checkSet
+	fromDesc string
+	toDesc   string
+	// helpers that load the results of each suite
+	fromHelper common.ObjectHelper
+	toHelper   common.ObjectHelper
+}
+
+func NewDiffResultsContext(streams genericclioptions.IOStreams) *DiffResultsContext {
+	return &DiffResultsContext{
+		CommandContext: common.CommandContext{
+			ConfigFlags: genericclioptions.NewConfigFlags(true),
+			IOStreams:   streams,
+		},
+		from: checkSet{},
+		to:   checkSet{},
+	}
+}
+
+// Complete sets all information required for comparing the results. Raw
+// results are read from the local filesystem, so no access to the cluster
+// is set up for them.
+func (o *DiffResultsContext) Complete(cmd *cobra.Command, args []string) error {
+	if o.Raw {
+		o.Args = args
+		return nil
+	}
+	return o.CommandContext.Complete(cmd, args)
+}
+
+// Validate ensures that all required arguments and flag values are provided
+func (o *DiffResultsContext) Validate() error {
+	if err := common.ValidateOutputFormat(o.Output, common.OutputFormatTable, common.OutputFormatJSON, common.OutputFormatYAML); err != nil {
+		return err
+	}
+
+	if o.Raw {
+		if len(o.Args) != 2 {
+			return fmt.Errorf("You need to specify exactly two directories to compare")
+		}
+		for _, dir := range o.Args {
+			if err := common.ValidateDirectory(dir); err != nil {
+				return err
+			}
+		}
+		o.fromDesc, o.toDesc = o.Args[0], o.Args[1]
+		return nil
+	}
+
+	from, to, err := common.ValidateObjectPairArgs(o.Args)
+	if err != nil {
+		return err
+	}
+	if from.Type != common.ComplianceSuite || to.Type != common.ComplianceSuite {
+		return fmt.Errorf("Invalid object type for this command")
+	}
+	o.fromDesc = "compliancesuite/" + from.Name
+	o.toDesc = "compliancesuite/" + to.Name
+	o.fromHelper = NewComplianceSuiteHelper(o.Kuser, from.Name, o.from, o.IOStreams)
+	o.toHelper = NewComplianceSuiteHelper(o.Kuser, to.Name, o.to, o.IOStreams)
+	return nil
+}
+
+func (o *DiffResultsContext) Run() error {
+	if err := o.load(); err != nil {
+		return err
+	}
+
+	d := diffChecks(o.from, o.to)
+	d.From, d.To = o.fromDesc, o.toDesc
+	if o.Output != common.OutputFormatTable {
+		return common.PrintStructured(o.Out, o.Output, d)
+	}
+	d.render(o.Out)
+	return nil
+}
+
+func (o *DiffResultsContext) load() error {
+	if o.Raw {
+		for idx, checks := range []checkSet{o.from, o.to} {
+			set, err := rawresults.LoadDir(o.Args[idx])
+			if err != nil {
+				return err
+			}
+			checks.addRawResults(set)
+		}
+		return nil
+	}
+
+	if err := o.fromHelper.Handle(); err != nil {
+		return err
+	}
+	return o.toHelper.Handle()
+}
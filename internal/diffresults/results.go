@@ -0,0 +1,227 @@
+// Package diffresults compares two sets of compliance results, e.g. before
+// and after applying remediations, to find the checks whose status changed.
+package diffresults
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/openshift/oc-compliance/internal/rawresults"
+)
+
+// ResultsDiffSchemaVersion is the version of the structured results diff
+const ResultsDiffSchemaVersion = "v1"
+
+const (
+	statusPass         = "PASS"
+	statusFail         = "FAIL"
+	statusInconsistent = "INCONSISTENT"
+)
+
+// checkKey identifies a check in both sets of results. The scope tells apart
+// the checks of the same rule that were done on different targets, e.g. on
+// master and worker nodes.
+type checkKey struct {
+	rule  string
+	scope string
+}
+
+type checkState struct {
+	status string
+	// name of the result object, if any
+	name string
+}
+
+// checkSet holds the status of each check of a set of results
+type checkSet map[checkKey]*checkState
+
+// add records the status of a check. If the check was already recorded with a
+// different status (e.g. on another node of the same role), it becomes
+// inconsistent, like the operator does when aggregating node results.
+func (s checkSet) add(rule, scope, status, name string) {
+	key := checkKey{rule, scope}
+	if state, found := s[key]; found {
+		if state.status != status {
+			state.status = statusInconsistent
+		}
+		return
+	}
+	s[key] = &checkState{status, name}
+}
+
+// addRawResults records the results loaded from raw result files
+func (s checkSet) addRawResults(set *rawresults.ResultSet) {
+	for idx := range set.Results {
+		res := &set.Results[idx]
+		s.add(res.ShortRuleID(), getRawScope(res), res.CheckStatus(), "")
+	}
+}
+
+// getRawScope gets what a raw result checked: the scanned profile, and the
+// scan it belongs to, so that the results of a role's nodes are aggregated
+// like in a ComplianceSuite. fetch-raw names the directory of each scan after
+// it, whereas the directories of the suites and of the result indexes only
+// depend on how the results were fetched, so they're ignored. Results that
+// aren't in a scan's directory are scoped by their target.
+func getRawScope(res *rawresults.Result) string {
+	scope := res.Target
+	elems := strings.Split(filepath.ToSlash(res.Source), "/")
+	for idx := len(elems) - 1; idx >= 0; idx-- {
+		if elems[idx] == "." || isIndexDir(elems[idx]) {
+			continue
+		}
+		scope = elems[idx]
+		break
+	}
+	if res.Profile == "" {
+		return scope
+	}
+	return res.Profile + "/" + scope
+}
+
+// isIndexDir tells whether a directory is named like the ones fetch-raw
+// stores each result index in
+func isIndexDir(name string) bool {
+	_, err := strconv.ParseUint(name, 10, 64)
+	return err == nil
+}
+
+func isFailing(status string) bool {
+	return status == statusFail || status == statusInconsistent
+}
+
+// ResultsDiff holds the checks whose status differs between two sets of
+// results
+type ResultsDiff struct {
+	SchemaVersion string `json:"schemaVersion"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+	// NewlyFailing checks are failing now but weren't before, or are new
+	// and failing
+	NewlyFailing []CheckChange `json:"newlyFailing"`
+	// NewlyPassing checks are passing now but weren't before
+	NewlyPassing []CheckChange `json:"newlyPassing"`
+	// Disappeared checks aren't in the new results
+	Disappeared []CheckChange `json:"disappeared"`
+	// Appeared checks are new, and not failing
+	Appeared []CheckChange `json:"appeared"`
+	// Changed checks had any other change of status (e.g. to MANUAL)
+	Changed []CheckChange `json:"changed"`
+}
+
+// CheckChange is a check whose status changed. The status is empty on the
+// side where the check is missing.
+type CheckChange struct {
+	Rule       string `json:"rule"`
+	Scope      string `json:"scope"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	FromResult string `json:"fromResult,omitempty"`
+	ToResult   string `json:"toResult,omitempty"`
+}
+
+// IsEmpty tells whether no check changed
+func (d *ResultsDiff) IsEmpty() bool {
+	return len(d.NewlyFailing)+len(d.NewlyPassing)+len(d.Disappeared)+len(d.Appeared)+len(d.Changed) == 0
+}
+
+func diffChecks(from, to checkSet) *ResultsDiff {
+	d := &ResultsDiff{
+		SchemaVersion: ResultsDiffSchemaVersion,
+		NewlyFailing:  []CheckChange{},
+		NewlyPassing:  []CheckChange{},
+		Disappeared:   []CheckChange{},
+		Appeared:      []CheckChange{},
+		Changed:       []CheckChange{},
+	}
+
+	for key, toState := range to {
+		change := CheckChange{
+			Rule:     key.rule,
+			Scope:    key.scope,
+			To:       toState.status,
+			ToResult: toState.name,
+		}
+		fromState, found := from[key]
+		if !found {
+			if isFailing(toState.status) {
+				d.NewlyFailing = append(d.NewlyFailing, change)
+			} else {
+				d.Appeared = append(d.Appeared, change)
+			}
+			continue
+		}
+
+		if fromState.status == toState.status {
+			continue
+		}
+		change.From = fromState.status
+		change.FromResult = fromState.name
+		switch {
+		case isFailing(toState.status) && !isFailing(fromState.status):
+			d.NewlyFailing = append(d.NewlyFailing, change)
+		case toState.status == statusPass:
+			d.NewlyPassing = append(d.NewlyPassing, change)
+		default:
+			d.Changed = append(d.Changed, change)
+		}
+	}
+
+	for key, fromState := range from {
+		if _, found := to[key]; !found {
+			d.Disappeared = append(d.Disappeared, CheckChange{
+				Rule:       key.rule,
+				Scope:      key.scope,
+				From:       fromState.status,
+				FromResult: fromState.name,
+			})
+		}
+	}
+
+	for _, changes := range [][]CheckChange{d.NewlyFailing, d.NewlyPassing, d.Disappeared, d.Appeared, d.Changed} {
+		sortChanges(changes)
+	}
+	return d
+}
+
+func sortChanges(changes []CheckChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Rule != changes[j].Rule {
+			return changes[i].Rule < changes[j].Rule
+		}
+		return changes[i].Scope < changes[j].Scope
+	})
+}
+
+func (d *ResultsDiff) render(w io.Writer) {
+	if d.IsEmpty() {
+		fmt.Fprintf(w, "No checks changed between %s and %s\n", d.From, d.To)
+		return
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Change", "Rule", "Scope", "From", "To"})
+	table.SetAutoWrapText(false)
+	sections := []struct {
+		name    string
+		changes []CheckChange
+	}{
+		{"newly failing", d.NewlyFailing},
+		{"newly passing", d.NewlyPassing},
+		{"disappeared", d.Disappeared},
+		{"appeared", d.Appeared},
+		{"changed", d.Changed},
+	}
+	for _, section := range sections {
+		for _, c := range section.changes {
+			table.Append([]string{section.name, c.Rule, c.Scope, c.From, c.To})
+		}
+	}
+	table.Render()
+}
@@ -0,0 +1,49 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+type ComplianceScanHelper struct {
+	kuser    common.KubeClientUser
+	gvk      schema.GroupVersionResource
+	kind     string
+	name     string
+	findings *FindingSet
+	resolver *common.RuleResolver
+	genericclioptions.IOStreams
+}
+
+func NewComplianceScanHelper(kuser common.KubeClientUser, name string, findings *FindingSet, resolver *common.RuleResolver, streams genericclioptions.IOStreams) common.ObjectHelper {
+	return &ComplianceScanHelper{
+		kuser:     kuser,
+		name:      name,
+		kind:      "ComplianceScan",
+		findings:  findings,
+		resolver:  resolver,
+		gvk:       common.GVR("compliancescans"),
+		IOStreams: streams,
+	}
+}
+
+func (h *ComplianceScanHelper) Handle() error {
+	// Get target resource
+	_, err := h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), h.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Unable to get resource %s/%s of type %s: %s", h.kuser.GetNamespace(), h.name, h.kind, err)
+	}
+
+	results, err := common.GetCheckResultsFromScan(h.kuser, h.name)
+	if err != nil {
+		return err
+	}
+
+	return h.findings.AddCheckResults(h.resolver, h.name, results)
+}
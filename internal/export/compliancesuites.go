@@ -0,0 +1,56 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+type ComplianceSuiteHelper struct {
+	kuser    common.KubeClientUser
+	gvk      schema.GroupVersionResource
+	kind     string
+	name     string
+	findings *FindingSet
+	resolver *common.RuleResolver
+	genericclioptions.IOStreams
+}
+
+func NewComplianceSuiteHelper(kuser common.KubeClientUser, name string, findings *FindingSet, resolver *common.RuleResolver, streams genericclioptions.IOStreams) common.ObjectHelper {
+	return &ComplianceSuiteHelper{
+		kuser:     kuser,
+		name:      name,
+		kind:      "ComplianceSuite",
+		findings:  findings,
+		resolver:  resolver,
+		gvk:       common.GVR("compliancesuites"),
+		IOStreams: streams,
+	}
+}
+
+func (h *ComplianceSuiteHelper) Handle() error {
+	// Get target resource
+	res, err := h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), h.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Unable to get resource %s/%s of type %s: %s", h.kuser.GetNamespace(), h.name, h.kind, err)
+	}
+
+	// Get needed data
+	scanNames, err := common.GetScanNamesFromSuite(res)
+	if err != nil {
+		return err
+	}
+
+	for _, scanName := range scanNames {
+		helper := NewComplianceScanHelper(h.kuser, scanName, h.findings, h.resolver, h.IOStreams)
+		if err = helper.Handle(); err != nil {
+			return fmt.Errorf("Unable to process results from suite %s: %s", h.name, err)
+		}
+	}
+	return nil
+}
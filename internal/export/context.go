@@ -0,0 +1,116 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+	"github.com/openshift/oc-compliance/internal/rawresults"
+)
+
+type ExportContext struct {
+	common.CommandContext
+
+	Format     string
+	RawDir     string
+	OutputFile string
+	Policy     JUnitPolicy
+
+	findings *FindingSet
+}
+
+func NewExportContext(streams genericclioptions.IOStreams) *ExportContext {
+	return &ExportContext{
+		CommandContext: common.CommandContext{
+			ConfigFlags: genericclioptions.NewConfigFlags(true),
+			IOStreams:   streams,
+		},
+		findings: NewFindingSet(),
+	}
+}
+
+// Complete sets all information required for exporting the results. Raw
+// results are read from the local filesystem, so no access to the cluster
+// is set up for them.
+func (o *ExportContext) Complete(cmd *cobra.Command, args []string) error {
+	if o.RawDir != "" {
+		o.Args = args
+		return nil
+	}
+	return o.CommandContext.Complete(cmd, args)
+}
+
+// Validate ensures that all required arguments and flag values are provided
+func (o *ExportContext) Validate() error {
+	switch o.Format {
+	case FormatSARIF:
+	case FormatJUnit:
+		if err := ValidatePolicy(StatusManual, o.Policy.Manual); err != nil {
+			return err
+		}
+		if err := ValidatePolicy(StatusInconsistent, o.Policy.Inconsistent); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("Invalid format '%s'. Must be one of: %s|%s", o.Format, FormatSARIF, FormatJUnit)
+	}
+
+	if o.RawDir != "" {
+		if len(o.Args) > 0 {
+			return fmt.Errorf("Objects can't be specified when exporting raw results")
+		}
+		if err := common.ValidateDirectory(o.RawDir); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	objref, err := common.ValidateObjectArgs(o.Args)
+	if err != nil {
+		return err
+	}
+
+	resolver := common.NewRuleResolver(o.Kuser)
+	switch objref.Type {
+	case common.ScanSettingBinding:
+		o.Helper = NewScanSettingBindingHelper(o.Kuser, objref.Name, o.findings, resolver, o.IOStreams)
+	case common.ComplianceSuite:
+		o.Helper = NewComplianceSuiteHelper(o.Kuser, objref.Name, o.findings, resolver, o.IOStreams)
+	case common.ComplianceScan:
+		o.Helper = NewComplianceScanHelper(o.Kuser, objref.Name, o.findings, resolver, o.IOStreams)
+	default:
+		return fmt.Errorf("Invalid object type for this command")
+	}
+	return nil
+}
+
+func (o *ExportContext) Run() error {
+	if o.RawDir != "" {
+		set, err := rawresults.LoadDir(o.RawDir)
+		if err != nil {
+			return err
+		}
+		o.findings.AddRawResults(set)
+	} else if err := o.Helper.Handle(); err != nil {
+		return err
+	}
+
+	var out io.Writer = o.Out
+	if o.OutputFile != "" {
+		f, err := os.OpenFile(o.OutputFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return fmt.Errorf("Unable to create %s: %s", o.OutputFile, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if o.Format == FormatJUnit {
+		return WriteJUnit(out, o.findings, o.Policy)
+	}
+	return WriteSARIF(out, o.findings)
+}
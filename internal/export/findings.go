@@ -0,0 +1,151 @@
+// Package export converts compliance results into formats understood by
+// external tools. The results are first gathered into a FindingSet, either
+// from the ComplianceCheckResults in the cluster or from fetched raw results,
+// so every format works with both sources.
+package export
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/openshift/oc-compliance/internal/common"
+	"github.com/openshift/oc-compliance/internal/rawresults"
+)
+
+// The statuses of a finding. These are the ComplianceCheckResult statuses;
+// raw results are mapped to them.
+const (
+	StatusPass          = "PASS"
+	StatusFail          = "FAIL"
+	StatusInfo          = "INFO"
+	StatusManual        = "MANUAL"
+	StatusError         = "ERROR"
+	StatusInconsistent  = "INCONSISTENT"
+	StatusNotApplicable = "NOT-APPLICABLE"
+	StatusSkip          = "SKIP"
+)
+
+// Rule describes a rule that findings refer to
+type Rule struct {
+	ID          string
+	Title       string
+	Description string
+	Rationale   string
+	Severity    string
+	// Controls per benchmark that the rule addresses
+	Controls map[string][]string
+}
+
+// Finding is the result of a rule on a scanned target
+type Finding struct {
+	// RuleID references a rule in the FindingSet
+	RuleID string
+	// Name identifies the finding, e.g. the ComplianceCheckResult name
+	Name string
+	// Scan is the scan that generated the finding. For raw results it's
+	// the directory the results were found in.
+	Scan string
+	// Target is where the rule was evaluated. e.g. a node
+	Target       string
+	Status       string
+	Severity     string
+	Instructions string
+}
+
+// FindingSet holds the findings to export and the rules they refer to
+type FindingSet struct {
+	// Rules indexed by ID
+	Rules    map[string]*Rule
+	Findings []Finding
+}
+
+func NewFindingSet() *FindingSet {
+	return &FindingSet{
+		Rules:    map[string]*Rule{},
+		Findings: []Finding{},
+	}
+}
+
+// SortedRules returns the rules ordered by ID
+func (s *FindingSet) SortedRules() []*Rule {
+	rules := make([]*Rule, 0, len(s.Rules))
+	for _, rule := range s.Rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+// AddCheckResults adds the ComplianceCheckResults of a scan to the set,
+// resolving the rule each of them was generated from
+func (s *FindingSet) AddCheckResults(resolver *common.RuleResolver, scanName string, results []unstructured.Unstructured) error {
+	sort.Slice(results, func(i, j int) bool { return results[i].GetName() < results[j].GetName() })
+	for idx := range results {
+		res := &results[idx]
+		rule, err := resolver.GetRuleForResult(res)
+		if err != nil {
+			return fmt.Errorf("Unable to get the rule of result %s: %s", res.GetName(), err)
+		}
+		if _, found := s.Rules[rule.GetName()]; !found {
+			s.Rules[rule.GetName()] = ruleFromObject(rule)
+		}
+
+		status, _, _ := unstructured.NestedString(res.Object, "status")
+		severity, _, _ := unstructured.NestedString(res.Object, "severity")
+		instructions, _, _ := unstructured.NestedString(res.Object, "instructions")
+		s.Findings = append(s.Findings, Finding{
+			RuleID:       rule.GetName(),
+			Name:         res.GetName(),
+			Scan:         scanName,
+			Target:       fmt.Sprintf("%s/%s", res.GetNamespace(), res.GetName()),
+			Status:       status,
+			Severity:     severity,
+			Instructions: instructions,
+		})
+	}
+	return nil
+}
+
+func ruleFromObject(obj *unstructured.Unstructured) *Rule {
+	rule := &Rule{
+		ID:       obj.GetName(),
+		Controls: common.GetControlsFromRule(obj),
+	}
+	rule.Title, _, _ = unstructured.NestedString(obj.Object, "title")
+	rule.Description, _, _ = unstructured.NestedString(obj.Object, "description")
+	rule.Rationale, _, _ = unstructured.NestedString(obj.Object, "rationale")
+	rule.Severity, _, _ = unstructured.NestedString(obj.Object, "severity")
+	return rule
+}
+
+// AddRawResults adds the results loaded from raw result files to the set.
+// The raw results carry no control annotations, so their rules have no
+// controls.
+func (s *FindingSet) AddRawResults(set *rawresults.ResultSet) {
+	for idx := range set.Results {
+		res := &set.Results[idx]
+		if _, found := s.Rules[res.RuleID]; !found {
+			rule := &Rule{
+				ID:       res.RuleID,
+				Title:    res.Title,
+				Severity: res.Severity,
+			}
+			if def, found := set.Rules[res.RuleID]; found {
+				rule.Description = def.Description.String()
+				rule.Rationale = def.Rationale.String()
+			}
+			s.Rules[res.RuleID] = rule
+		}
+
+		s.Findings = append(s.Findings, Finding{
+			RuleID:   res.RuleID,
+			Name:     fmt.Sprintf("%s-%s", res.Target, res.ShortRuleID()),
+			Scan:     res.Source,
+			Target:   res.Target,
+			Status:   res.CheckStatus(),
+			Severity: res.Severity,
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// FormatJUnit is the JUnit XML format understood by most CI systems
+	FormatJUnit = "junit"
+
+	// PolicySkipped reports the result as a skipped test case
+	PolicySkipped = "skipped"
+	// PolicyError reports the result as a test case with an error
+	PolicyError = "error"
+	// PolicyFailure reports the result as a failed test case
+	PolicyFailure = "failure"
+)
+
+// JUnitPolicy sets how the results that are neither a pass nor a failure are
+// reported
+type JUnitPolicy struct {
+	Manual       string
+	Inconsistent string
+}
+
+// ValidatePolicy checks that the policy for the given status is known
+func ValidatePolicy(status, policy string) error {
+	switch policy {
+	case PolicySkipped, PolicyError, PolicyFailure:
+		return nil
+	}
+	return fmt.Errorf("Invalid policy '%s' for %s results. Must be one of: %s|%s|%s",
+		policy, status, PolicySkipped, PolicyError, PolicyFailure)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the findings as JUnit XML, with a test suite per scan and
+// a test case per finding. Failed checks are reported as failures and the
+// checks that errored as errors. The policy sets how the checks that need
+// manual verification or that have inconsistent results are reported.
+func WriteJUnit(w io.Writer, set *FindingSet, policy JUnitPolicy) error {
+	suites := junitTestSuites{Name: toolName}
+	suiteIndexes := map[string]int{}
+
+	for idx := range set.Findings {
+		finding := &set.Findings[idx]
+		sidx, found := suiteIndexes[finding.Scan]
+		if !found {
+			sidx = len(suites.Suites)
+			suiteIndexes[finding.Scan] = sidx
+			suites.Suites = append(suites.Suites, junitTestSuite{Name: finding.Scan})
+		}
+		suite := &suites.Suites[sidx]
+
+		tc := junitTestCase{
+			Name:      finding.Name,
+			ClassName: finding.Scan,
+		}
+		switch finding.Status {
+		case StatusFail:
+			tc.Failure = junitFindingMessage(set, finding)
+		case StatusError:
+			tc.Error = junitFindingMessage(set, finding)
+		case StatusManual:
+			setPolicyOutcome(&tc, policy.Manual, junitFindingMessage(set, finding))
+		case StatusInconsistent:
+			setPolicyOutcome(&tc, policy.Inconsistent, junitFindingMessage(set, finding))
+		case StatusNotApplicable, StatusSkip:
+			tc.Skipped = &junitMessage{Message: finding.Status}
+		}
+
+		suite.Tests++
+		switch {
+		case tc.Failure != nil:
+			suite.Failures++
+		case tc.Error != nil:
+			suite.Errors++
+		case tc.Skipped != nil:
+			suite.Skipped++
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	for idx := range suites.Suites {
+		suites.Tests += suites.Suites[idx].Tests
+		suites.Failures += suites.Suites[idx].Failures
+		suites.Errors += suites.Suites[idx].Errors
+		suites.Skipped += suites.Suites[idx].Skipped
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(&suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func setPolicyOutcome(tc *junitTestCase, policy string, msg *junitMessage) {
+	switch policy {
+	case PolicyFailure:
+		tc.Failure = msg
+	case PolicyError:
+		tc.Error = msg
+	default:
+		tc.Skipped = msg
+	}
+}
+
+// junitFindingMessage describes the finding with the rule's title,
+// description and the instructions to verify it
+func junitFindingMessage(set *FindingSet, finding *Finding) *junitMessage {
+	msg := &junitMessage{
+		Message: finding.Status,
+		Type:    finding.Severity,
+	}
+	rule, found := set.Rules[finding.RuleID]
+	if !found {
+		return msg
+	}
+
+	title := rule.Title
+	if title == "" {
+		title = rule.ID
+	}
+	msg.Message = fmt.Sprintf("%s: %s", finding.Status, title)
+	parts := []string{}
+	if rule.Description != "" {
+		parts = append(parts, rule.Description)
+	}
+	if finding.Instructions != "" {
+		parts = append(parts, "Instructions:\n"+finding.Instructions)
+	}
+	msg.Text = strings.Join(parts, "\n\n")
+	return msg
+}
@@ -0,0 +1,295 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	// FormatSARIF is the SARIF 2.1.0 format
+	FormatSARIF = "sarif"
+
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "oc-compliance"
+	toolURI      = "https://github.com/openshift/oc-compliance"
+)
+
+// The subset of the SARIF 2.1.0 object model that is needed to describe
+// compliance results.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool            `json:"tool"`
+	Taxonomies []sarifToolComponent `json:"taxonomies,omitempty"`
+	Results    []sarifResult        `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifToolComponent `json:"driver"`
+}
+
+type sarifToolComponent struct {
+	Name           string                     `json:"name"`
+	InformationURI string                     `json:"informationUri,omitempty"`
+	Rules          []sarifReportingDescriptor `json:"rules,omitempty"`
+	Taxa           []sarifReportingDescriptor `json:"taxa,omitempty"`
+}
+
+type sarifReportingDescriptor struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name,omitempty"`
+	ShortDescription     *sarifMessage          `json:"shortDescription,omitempty"`
+	FullDescription      *sarifMessage          `json:"fullDescription,omitempty"`
+	Help                 *sarifMessage          `json:"help,omitempty"`
+	DefaultConfiguration *sarifConfiguration    `json:"defaultConfiguration,omitempty"`
+	Relationships        []sarifRelationship    `json:"relationships,omitempty"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRelationship struct {
+	Target sarifDescriptorReference `json:"target"`
+	Kinds  []string                 `json:"kinds"`
+}
+
+type sarifDescriptorReference struct {
+	ID            string                       `json:"id"`
+	Index         int                          `json:"index"`
+	ToolComponent *sarifToolComponentReference `json:"toolComponent,omitempty"`
+}
+
+type sarifToolComponentReference struct {
+	Name  string `json:"name"`
+	Index int    `json:"index"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	RuleIndex  int                    `json:"ruleIndex"`
+	Kind       string                 `json:"kind"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// severityLevels maps the rule severities to SARIF levels
+var severityLevels = map[string]string{
+	"high":   "error",
+	"medium": "warning",
+	"low":    "note",
+}
+
+// securitySeverities maps the rule severities to the numeric scores that
+// some SARIF consumers (e.g. GitHub code scanning) use to rank findings
+var securitySeverities = map[string]string{
+	"high":   "8.0",
+	"medium": "5.0",
+	"low":    "2.0",
+}
+
+// statusKinds maps the finding statuses to SARIF result kinds. Only failures
+// have a level other than "none".
+var statusKinds = map[string]string{
+	StatusPass:          "pass",
+	StatusFail:          "fail",
+	StatusInconsistent:  "fail",
+	StatusManual:        "review",
+	StatusInfo:          "informational",
+	StatusNotApplicable: "notApplicable",
+	StatusSkip:          "notApplicable",
+	StatusError:         "open",
+}
+
+func severityLevel(severity string) string {
+	if level, found := severityLevels[strings.ToLower(severity)]; found {
+		return level
+	}
+	return "note"
+}
+
+// WriteSARIF writes the findings as a SARIF log with a single run
+func WriteSARIF(w io.Writer, set *FindingSet) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifToolComponent{
+				Name:           toolName,
+				InformationURI: toolURI,
+			},
+		},
+		Taxonomies: buildTaxonomies(set),
+		Results:    []sarifResult{},
+	}
+
+	taxonomyIndexes := map[string]int{}
+	taxonIndexes := map[string]int{}
+	for tidx, taxonomy := range run.Taxonomies {
+		taxonomyIndexes[taxonomy.Name] = tidx
+		for idx, taxon := range taxonomy.Taxa {
+			taxonIndexes[taxonomy.Name+"/"+taxon.ID] = idx
+		}
+	}
+
+	ruleIndexes := map[string]int{}
+	for idx, rule := range set.SortedRules() {
+		ruleIndexes[rule.ID] = idx
+		desc := sarifReportingDescriptor{
+			ID:   rule.ID,
+			Name: rule.ID,
+			DefaultConfiguration: &sarifConfiguration{
+				Level: severityLevel(rule.Severity),
+			},
+			Properties: map[string]interface{}{
+				"severity": rule.Severity,
+			},
+		}
+		if rule.Title != "" {
+			desc.ShortDescription = &sarifMessage{Text: rule.Title}
+		}
+		if rule.Description != "" {
+			desc.FullDescription = &sarifMessage{Text: rule.Description}
+		}
+		if rule.Rationale != "" {
+			desc.Help = &sarifMessage{Text: rule.Rationale}
+		}
+		if score, found := securitySeverities[strings.ToLower(rule.Severity)]; found {
+			desc.Properties["security-severity"] = score
+		}
+		for _, benchmark := range sortedBenchmarks(rule.Controls) {
+			for _, ctrl := range rule.Controls[benchmark] {
+				desc.Relationships = append(desc.Relationships, sarifRelationship{
+					Target: sarifDescriptorReference{
+						ID:    ctrl,
+						Index: taxonIndexes[benchmark+"/"+ctrl],
+						ToolComponent: &sarifToolComponentReference{
+							Name:  benchmark,
+							Index: taxonomyIndexes[benchmark],
+						},
+					},
+					Kinds: []string{"relevant"},
+				})
+			}
+		}
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, desc)
+	}
+
+	for idx := range set.Findings {
+		finding := &set.Findings[idx]
+		kind, found := statusKinds[finding.Status]
+		if !found {
+			kind = "open"
+		}
+		level := "none"
+		if kind == "fail" {
+			level = severityLevel(finding.Severity)
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    finding.RuleID,
+			RuleIndex: ruleIndexes[finding.RuleID],
+			Kind:      kind,
+			Level:     level,
+			Message:   sarifMessage{Text: findingMessage(set, finding)},
+			Locations: []sarifLocation{{
+				LogicalLocations: []sarifLogicalLocation{{
+					Name:               finding.Name,
+					FullyQualifiedName: finding.Target,
+					Kind:               "resource",
+				}},
+			}},
+			Properties: map[string]interface{}{
+				"status":   finding.Status,
+				"severity": finding.Severity,
+				"scan":     finding.Scan,
+			},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// buildTaxonomies creates a taxonomy per benchmark with the controls that
+// the rules address
+func buildTaxonomies(set *FindingSet) []sarifToolComponent {
+	seen := map[string]bool{}
+	controls := map[string][]string{}
+	for _, rule := range set.Rules {
+		for benchmark, ctrls := range rule.Controls {
+			for _, ctrl := range ctrls {
+				if seen[benchmark+"/"+ctrl] {
+					continue
+				}
+				seen[benchmark+"/"+ctrl] = true
+				controls[benchmark] = append(controls[benchmark], ctrl)
+			}
+		}
+	}
+
+	taxonomies := []sarifToolComponent{}
+	for _, benchmark := range sortedBenchmarks(controls) {
+		taxonomy := sarifToolComponent{Name: benchmark}
+		ids := controls[benchmark]
+		sort.Strings(ids)
+		for _, ctrl := range ids {
+			taxonomy.Taxa = append(taxonomy.Taxa, sarifReportingDescriptor{ID: ctrl})
+		}
+		taxonomies = append(taxonomies, taxonomy)
+	}
+	return taxonomies
+}
+
+// findingMessage describes the finding. The instructions are added to the
+// results that need to be checked manually.
+func findingMessage(set *FindingSet, finding *Finding) string {
+	title := finding.RuleID
+	if rule, found := set.Rules[finding.RuleID]; found && rule.Title != "" {
+		title = rule.Title
+	}
+	msg := fmt.Sprintf("%s: %s", finding.Status, title)
+	if finding.Instructions != "" && finding.Status != StatusPass {
+		msg = fmt.Sprintf("%s\n\n%s", msg, finding.Instructions)
+	}
+	return msg
+}
+
+func sortedBenchmarks(controls map[string][]string) []string {
+	keys := make([]string, 0, len(controls))
+	for key := range controls {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -56,7 +56,7 @@ func (o *FetchFixesContext) Validate() error {
 
 	switch objref.Type {
 	case common.Rule:
-		o.Helper = NewRuleHelper(o.Kuser, objref.Name, o.OutputPath, o.MCRoles, o.EMB, o.IOStreams)
+		o.Helper = NewRuleHelper(o.Kuser, objref.Name, o.OutputPath, o.MCRoles, o.EMB, common.NewObjectCache(o.Kuser), o.IOStreams)
 	case common.Profile:
 		o.Helper = NewProfileHelper(o.Kuser, objref.Name, o.OutputPath, o.MCRoles, o.EMB, o.IOStreams)
 	case common.ComplianceRemediation:
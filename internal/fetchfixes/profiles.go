@@ -49,8 +49,17 @@ func (h *ProfileHelper) Handle() error {
 	}
 
 	rules, err := common.GetRulesFromProfile(p)
+	if err != nil {
+		return err
+	}
+
+	// Fetch all the rules of the bundle at once instead of one by one
+	cache := common.NewObjectCache(h.kuser)
+	if err := cache.PrefetchRules(p.GetLabels()[common.ProfileBundleLabel]); err != nil {
+		return err
+	}
 	for _, r := range rules {
-		rh := NewRuleHelper(h.kuser, r, h.outputPath, h.mcRoles, h.emb, h.IOStreams)
+		rh := NewRuleHelper(h.kuser, r, h.outputPath, h.mcRoles, h.emb, cache, h.IOStreams)
 		rh.Handle()
 	}
 	return nil
@@ -1,10 +1,8 @@
 package fetchfixes
 
 import (
-	"context"
 	"fmt"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	k8sserial "k8s.io/apimachinery/pkg/runtime/serializer/json"
@@ -21,11 +19,15 @@ type RuleHelper struct {
 	kind  string
 	name  string
 	emb   emb.ExtraManifestBuilder
+	cache *common.ObjectCache
 }
 
+// NewRuleHelper creates a helper for persisting the fixes of a rule. The rule
+// is taken from the given cache, which may have been prefetched with the
+// rules of a profile.
 func NewRuleHelper(
 	kuser common.KubeClientUser, name string, outputPath string, mcRoles []string,
-	emb emb.ExtraManifestBuilder, streams genericclioptions.IOStreams) common.ObjectHelper {
+	emb emb.ExtraManifestBuilder, cache *common.ObjectCache, streams genericclioptions.IOStreams) common.ObjectHelper {
 	return &RuleHelper{
 		FixPersister: FixPersister{
 			outputPath: outputPath,
@@ -35,6 +37,7 @@ func NewRuleHelper(
 		kuser: kuser,
 		name:  name,
 		emb:   emb,
+		cache: cache,
 		kind:  "Rule",
 		gvk: schema.GroupVersionResource{
 			Group:    common.CmpAPIGroup,
@@ -45,7 +48,7 @@ func NewRuleHelper(
 }
 
 func (h *RuleHelper) Handle() error {
-	r, err := h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), h.name, metav1.GetOptions{})
+	r, err := h.cache.Get(h.gvk, h.name)
 	if err != nil {
 		return err
 	}
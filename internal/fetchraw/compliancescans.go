@@ -4,16 +4,19 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
@@ -28,6 +31,9 @@ const (
 	rawResultsMountPath = "raw-results"
 	cmdLabelKey         = "fetch-compliance-results"
 	objNameLabelKey     = "fetch-compliance-results/obj-name"
+	// extractorPodLifetime bounds the time it takes to wait for the
+	// extractor pod and to copy all the selected result sets from it
+	extractorPodLifetime = time.Hour
 )
 
 type ComplianceScanHelper struct {
@@ -38,18 +44,23 @@ type ComplianceScanHelper struct {
 	name       string
 	outputPath string
 	image      string
-	html       bool
+	transport  string
+	renderer   ReportRenderer
+	indexes    IndexSelection
 	genericclioptions.IOStreams
 }
 
-func NewComplianceScanHelper(kuser common.KubeClientUser, name, outputPath, image string, html bool, streams genericclioptions.IOStreams) common.ObjectHelper {
+func NewComplianceScanHelper(kuser common.KubeClientUser, name, outputPath, image, transport string, renderer ReportRenderer, indexes IndexSelection,
+	streams genericclioptions.IOStreams) common.ObjectHelper {
 	return &ComplianceScanHelper{
 		kuser:      kuser,
 		name:       name,
 		kind:       "ComplianceScan",
 		outputPath: outputPath,
 		image:      image,
-		html:       html,
+		transport:  transport,
+		renderer:   renderer,
+		indexes:    indexes,
 		gvk: schema.GroupVersionResource{
 			Group:    common.CmpAPIGroup,
 			Version:  common.CmpResourceVersion,
@@ -101,35 +112,145 @@ func (h *ComplianceScanHelper) Handle() error {
 		return fmt.Errorf("Malformed raw result storage reference. No namespace available. Check the %s object's status", h.kind)
 	}
 
+	if err := h.fetchWithTransport(res.GetName(), rsnamespace, claimName, ci); err != nil {
+		return err
+	}
+
+	if h.renderer != nil {
+		return h.generateHTMLReports()
+	}
+	return nil
+}
+
+// fetchWithTransport fetches the results with the selected transport. The
+// automatic one prefers a pod that already mounts the results volume, which
+// avoids creating an extractor pod, and falls back to the other transports
+// when it fails.
+func (h *ComplianceScanHelper) fetchWithTransport(objName, ns, claimName string, currentIndex int64) error {
+	// errors of the transports that were tried, reported if all of them fail
+	errs := []error{}
+	if h.transport == TransportAuto || h.transport == TransportResultServer {
+		src, err := findResultServer(h.kuser, ns, objName, claimName)
+		if err == nil {
+			fmt.Fprintf(h.Out, "Fetching raw compliance results for scan '%s' from pod '%s'.\n", h.name, src.pod)
+			err = h.fetchResults(src, currentIndex, h.copyWithTar)
+		}
+		if err == nil || h.transport == TransportResultServer {
+			return err
+		}
+		fmt.Fprintf(h.ErrOut, "Unable to fetch the results of scan '%s' from a running pod, falling back to an extractor pod: %s\n", h.name, err)
+		errs = append(errs, fmt.Errorf("%s transport: %s", TransportResultServer, err))
+	}
+
 	// Create extractor pod
-	extractorPod := getPVCExtractorPod(res.GetName(), rsnamespace, h.image, claimName)
-	extractorPod, err = h.kuser.Clientset().CoreV1().Pods(rsnamespace).Create(context.TODO(), extractorPod, metav1.CreateOptions{})
+	extractorPod := getPVCExtractorPod(objName, ns, h.image, claimName)
+	extractorPod, err := h.kuser.Clientset().CoreV1().Pods(ns).Create(context.TODO(), extractorPod, metav1.CreateOptions{})
 	if err != nil && !kerrors.IsAlreadyExists(err) {
-		return err
+		return utilerrors.NewAggregate(append(errs, err))
 	}
+	defer h.deleteExtractorPod(ns, extractorPod.GetName())
 
 	// wait for extractor pod
-	err = h.waitForExtractorPod(rsnamespace, res.GetName(), extractorPod.GetName())
+	err = h.waitForExtractorPod(ns, objName, extractorPod.GetName())
 	if err != nil {
-		return err
+		return utilerrors.NewAggregate(append(errs, err))
+	}
+
+	src := resultSource{
+		namespace: ns,
+		pod:       extractorPod.GetName(),
+		mountPath: "/" + rawResultsMountPath,
+	}
+	var fetchErr error
+	if h.transport == TransportCp {
+		fetchErr = h.fetchResults(src, currentIndex, h.copyFromPod)
+	} else {
+		fetchErr = h.fetchResults(src, currentIndex, h.copyWithTar)
+		if fetchErr != nil && h.transport == TransportAuto {
+			fmt.Fprintf(h.ErrOut, "Unable to stream the results of scan '%s', falling back to copying them: %s\n", h.name, fetchErr)
+			errs = append(errs, fmt.Errorf("%s transport: %s", TransportExec, fetchErr))
+			fetchErr = h.fetchResults(src, currentIndex, h.copyFromPod)
+			if fetchErr != nil {
+				fetchErr = fmt.Errorf("%s transport: %s", TransportCp, fetchErr)
+			}
+		}
+	}
+
+	if fetchErr != nil {
+		return utilerrors.NewAggregate(append(errs, fetchErr))
+	}
+	return nil
+}
+
+// deleteExtractorPod deletes the extractor pod, so it doesn't keep the
+// results volume mounted. Failing to do so doesn't fail the fetch, as the pod
+// exits by itself after a while anyway.
+func (h *ComplianceScanHelper) deleteExtractorPod(ns, podName string) {
+	var zeroGP int64 = 0
+	err := h.kuser.Clientset().CoreV1().Pods(ns).Delete(context.TODO(), podName, metav1.DeleteOptions{
+		GracePeriodSeconds: &zeroGP,
+	})
+	if err != nil && !kerrors.IsNotFound(err) {
+		fmt.Fprintf(h.ErrOut, "Warning: Unable to delete the extractor pod %s/%s: %s\n", ns, podName, err)
+	}
+}
+
+// fetchResults copies the selected result sets from the result source. The
+// current result set is copied directly into the output path, whereas the
+// historical ones are copied into a directory per index.
+func (h *ComplianceScanHelper) fetchResults(src resultSource, currentIndex int64, copyFn copyFunc) error {
+	if h.indexes.CurrentOnly() {
+		srcPath := path.Join(src.mountPath, strconv.FormatInt(currentIndex, 10))
+		if err := copyFn(src, srcPath, h.outputPath); err != nil {
+			return err
+		}
+		fmt.Fprintf(h.Out, "The raw compliance results are avaliable in the following directory: %s\n", h.outputPath)
+		return nil
+	}
+
+	indexes, err := selectIndexes(h.kuser, src.namespace, src.pod, src.container, src.mountPath, h.indexes)
+	if err != nil {
+		return fmt.Errorf("Unable to select the results of scan %s: %s", h.name, err)
+	}
+	for _, idx := range indexes {
+		indexDir := filepath.Join(h.outputPath, strconv.FormatInt(idx, 10))
+		// The directory may exist already if a previous transport failed
+		if err := os.MkdirAll(indexDir, 0700); err != nil {
+			return fmt.Errorf("Unable to create directory %s: %s", indexDir, err)
+		}
+		srcPath := path.Join(src.mountPath, strconv.FormatInt(idx, 10))
+		if err := copyFn(src, srcPath, indexDir); err != nil {
+			return err
+		}
+		fmt.Fprintf(h.Out, "The raw compliance results of index %d are avaliable in the following directory: %s\n", idx, indexDir)
 	}
+	return nil
+}
 
+func (h *ComplianceScanHelper) copyWithTar(src resultSource, srcPath, dst string) error {
+	return copyWithTar(h.kuser, src, srcPath, dst)
+}
+
+// copyFromPod copies the given path of the pod into the destination
+// directory
+func (h *ComplianceScanHelper) copyFromPod(src resultSource, srcPath, dst string) error {
 	cf := NewFetchRawOptions(h.IOStreams).ConfigFlags
 	f := util.NewFactory(cf)
 	cmd := cp.NewCmdCp(f, h.IOStreams)
 
 	opts := cp.NewCopyOptions(h.IOStreams)
-	opts.Namespace = rsnamespace
+	opts.Namespace = src.namespace
 
-	podName := extractorPod.GetName()
-	path := fmt.Sprintf("%s/%d", rawResultsMountPath, ci)
+	// The path is relative to the working directory of the pod, which
+	// avoids tar's warnings about leading slashes
 	cpargs := []string{
-		fmt.Sprintf("%s/%s:%s", rsnamespace, podName, path),
-		h.outputPath,
+		fmt.Sprintf("%s/%s:%s", src.namespace, src.pod, strings.TrimPrefix(srcPath, "/")),
+		dst,
 	}
-	if err = opts.Complete(f, cmd, cpargs); err != nil {
+	if err := opts.Complete(f, cmd, cpargs); err != nil {
 		return err
 	}
+	opts.Container = src.container
 
 	// We have to do this because the client associated to kuser has
 	// workarounds for the GroupVersion and Negotiation parameters. If we
@@ -140,29 +261,14 @@ func (h *ComplianceScanHelper) Handle() error {
 	// them and we'll get an error.
 	c := restclient.CopyConfig(h.kuser.GetConfig())
 	cs, err := kubernetes.NewForConfig(c)
-	opts.ClientConfig = c
-	opts.Clientset = cs
-
-	// run kubectl cp
-	if err = opts.Run(); err != nil {
-		return err
-	}
-
-	fmt.Fprintf(h.Out, "The raw compliance results are avaliable in the following directory: %s\n", h.outputPath)
-
-	// delete extractor pod
-	var zeroGP int64 = 0
-	err = h.kuser.Clientset().CoreV1().Pods(rsnamespace).Delete(context.TODO(), extractorPod.GetName(), metav1.DeleteOptions{
-		GracePeriodSeconds: &zeroGP,
-	})
 	if err != nil {
 		return err
 	}
+	opts.ClientConfig = c
+	opts.Clientset = cs
 
-	if h.html {
-		return h.generateHTMLReports()
-	}
-	return nil
+	// run kubectl cp
+	return opts.Run()
 }
 
 func (h *ComplianceScanHelper) getScanPhase(obj *unstructured.Unstructured) (string, error) {
@@ -235,53 +341,40 @@ func (h *ComplianceScanHelper) waitForExtractorPod(ns, objName, generatedPodName
 }
 
 func (h *ComplianceScanHelper) generateHTMLReports() error {
-	var wg sync.WaitGroup
-	done := make(chan bool)
-	errors := make(chan error)
-	reportFuncs := []func(){}
-	// We always return nil here
-	filepath.Walk(h.outputPath, func(path string, info os.FileInfo, err error) error {
-		if info.IsDir() {
-			return nil
+	arfPaths := []string{}
+	err := filepath.Walk(h.outputPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
 		}
-		if !hasExpectedARFExtension(path) {
+		if info.IsDir() || !hasExpectedARFExtension(path) {
 			return nil
 		}
-		reportf := func() {
-			reportFile := replaceARFforHTMLExt(path)
-			reportcmd := exec.Command("oscap", "xccdf", "generate", "report",
-				"--output", reportFile, path)
-			out, err := reportcmd.CombinedOutput()
-			if err != nil {
-				fmt.Fprintf(h.Out, string(out))
-				errors <- err
-			} else {
-				fmt.Fprintf(h.Out, "An HTML report is available at %s\n", reportFile)
-			}
-			wg.Done()
-		}
-		reportFuncs = append(reportFuncs, reportf)
+		arfPaths = append(arfPaths, path)
 		return nil
 	})
-
-	wg.Add(len(reportFuncs))
-	for _, f := range reportFuncs {
-		go f()
+	if err != nil {
+		return err
 	}
 
-	go func() {
-		wg.Wait()
-		close(done)
-	}()
-
-	select {
-	case <-done:
-		break
-	case err := <-errors:
-		close(errors)
-		return err
+	var wg sync.WaitGroup
+	errors := make(chan error, len(arfPaths))
+	wg.Add(len(arfPaths))
+	for _, path := range arfPaths {
+		go func(path string) {
+			defer wg.Done()
+			reportFile := replaceARFforHTMLExt(path)
+			if err := h.renderer.Render(path, reportFile); err != nil {
+				errors <- err
+				return
+			}
+			fmt.Fprintf(h.Out, "An HTML report is available at %s\n", reportFile)
+		}(path)
 	}
-	return nil
+	wg.Wait()
+	close(errors)
+
+	// Report the first error, if any
+	return <-errors
 }
 
 func getPVCExtractorPodLabels(objName string) map[string]string {
@@ -291,6 +384,10 @@ func getPVCExtractorPodLabels(objName string) map[string]string {
 	}
 }
 
+// getPVCExtractorPod creates the definition of a pod that mounts the results
+// volume. It's deleted once the results are fetched, but it still exits by
+// itself after extractorPodLifetime, so it doesn't keep the volume mounted if
+// the command is interrupted.
 func getPVCExtractorPod(objName, ns, image, claimName string) *corev1.Pod {
 	bFalse := false
 	bTrue := true
@@ -311,7 +408,7 @@ func getPVCExtractorPod(objName, ns, image, claimName string) *corev1.Pod {
 				{
 					Name:    "pv-extract-pod",
 					Image:   image,
-					Command: []string{"sleep", "300"},
+					Command: []string{"sleep", strconv.Itoa(int(extractorPodLifetime.Seconds()))},
 					SecurityContext: &corev1.SecurityContext{
 						Capabilities: &corev1.Capabilities{
 							Drop: []corev1.Capability{"ALL"},
@@ -21,18 +21,25 @@ type ComplianceSuiteHelper struct {
 	kind       string
 	outputPath string
 	image      string
-	html       bool
+	transport  string
+	renderer   ReportRenderer
+	indexes    IndexSelection
+	parallel   common.ParallelOptions
 	genericclioptions.IOStreams
 }
 
-func NewComplianceSuiteHelper(kuser common.KubeClientUser, name, outputPath, image string, html bool, streams genericclioptions.IOStreams) common.ObjectHelper {
+func NewComplianceSuiteHelper(kuser common.KubeClientUser, name, outputPath, image, transport string, renderer ReportRenderer, indexes IndexSelection,
+	parallel common.ParallelOptions, streams genericclioptions.IOStreams) common.ObjectHelper {
 	return &ComplianceSuiteHelper{
 		kuser:      kuser,
 		name:       name,
 		kind:       "ComplianceSuite",
 		outputPath: outputPath,
-		html:       html,
+		renderer:   renderer,
 		image:      image,
+		transport:  transport,
+		indexes:    indexes,
+		parallel:   parallel,
 		gvk: schema.GroupVersionResource{
 			Group:    common.CmpAPIGroup,
 			Version:  common.CmpResourceVersion,
@@ -57,15 +64,19 @@ func (h *ComplianceSuiteHelper) Handle() error {
 
 	fmt.Fprintf(h.Out, "Fetching results for %s scans: %s\n", h.name, strings.Join(scanNames, ", "))
 
-	for _, scanName := range scanNames {
+	streams := h.IOStreams
+	if h.parallel.Parallelism > 1 {
+		streams.Out = common.NewSyncWriter(h.Out)
+		streams.ErrOut = common.NewSyncWriter(h.ErrOut)
+	}
+	errs := common.RunParallel(scanNames, h.parallel, func(scanName string) error {
 		scanDir := path.Join(h.outputPath, scanName)
 		if err := os.Mkdir(scanDir, 0700); err != nil {
 			return fmt.Errorf("Unable to create directory %s: %s", scanDir, err)
 		}
-		helper := NewComplianceScanHelper(h.kuser, scanName, scanDir, h.image, h.html, h.IOStreams)
-		if err = helper.Handle(); err != nil {
-			return fmt.Errorf("Unable to process results from suite %s: %s", h.name, err)
-		}
-	}
-	return nil
+		helper := NewComplianceScanHelper(h.kuser, scanName, scanDir, h.image, h.transport, h.renderer, h.indexes, streams)
+		return helper.Handle()
+	})
+	failure := fmt.Sprintf("Unable to fetch the results of suite %s", h.name)
+	return common.SummarizeErrors(streams.Out, "ComplianceScan", scanNames, errs, failure)
 }
@@ -0,0 +1,58 @@
+package fetchraw
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/kubectl/pkg/scheme"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+// execInPod runs the given command in a container of a running pod and
+// returns its standard output. If the container name is empty, the pod must
+// only have one container.
+func execInPod(kuser common.KubeClientUser, ns, podName, container string, command []string) (string, error) {
+	var stdout bytes.Buffer
+	if err := streamFromPod(kuser, ns, podName, container, command, &stdout); err != nil {
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// streamFromPod runs the given command in a container of a running pod,
+// writing its standard output to the given writer as it's produced
+func streamFromPod(kuser common.KubeClientUser, ns, podName, container string, command []string, stdout io.Writer) error {
+	req := kuser.Clientset().CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(ns).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(kuser.GetConfig(), "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	err = executor.StreamWithContext(context.TODO(), remotecommand.StreamOptions{
+		Stdout: stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		return fmt.Errorf("Unable to run '%s' in pod %s/%s: %s: %s",
+			strings.Join(command, " "), ns, podName, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
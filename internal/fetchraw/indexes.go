@@ -0,0 +1,147 @@
+package fetchraw
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+// CurrentIndex is used to request the result set of the scan's currentIndex
+const CurrentIndex int64 = -1
+
+// IndexSelection describes which of the raw result sets kept by the operator
+// in the results volume should be fetched. Each scan run is stored in a
+// directory named after its index.
+type IndexSelection struct {
+	// Index is the specific index to fetch, or CurrentIndex
+	Index int64
+	// All selects every index available in the volume
+	All bool
+	// Since selects the indexes whose results were written after the
+	// given time. It's ignored if zero.
+	Since time.Time
+}
+
+// CurrentOnly tells whether only the current result set was requested. In
+// that case the results are fetched directly into the output directory
+// instead of a directory per index.
+func (s IndexSelection) CurrentOnly() bool {
+	return s.Index == CurrentIndex && !s.All && s.Since.IsZero()
+}
+
+// ParseSince parses either an RFC3339 timestamp or a duration relative to
+// the current time (e.g. "48h")
+func ParseSince(since string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid time '%s'. Must be an RFC3339 timestamp (e.g. 2006-01-02T15:04:05Z) or a duration (e.g. 48h)", since)
+	}
+	return time.Now().Add(-d), nil
+}
+
+// listIndexes lists the result set indexes available in the raw results
+// directory of a pod
+func listIndexes(kuser common.KubeClientUser, ns, podName, container, resultsDir string) ([]int64, error) {
+	out, err := execInPod(kuser, ns, podName, container, []string{"ls", "-1", resultsDir})
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := []int64{}
+	for _, entry := range strings.Fields(out) {
+		// Ignore anything that isn't a result set (e.g. lost+found)
+		idx, err := strconv.ParseInt(entry, 10, 64)
+		if err != nil {
+			continue
+		}
+		indexes = append(indexes, idx)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+	return indexes, nil
+}
+
+// getIndexTimes gets the modification time of the result set directories
+// of the given indexes
+func getIndexTimes(kuser common.KubeClientUser, ns, podName, container, resultsDir string, indexes []int64) (map[int64]time.Time, error) {
+	cmd := []string{"stat", "-c", "%n %Y"}
+	for _, idx := range indexes {
+		cmd = append(cmd, path.Join(resultsDir, strconv.FormatInt(idx, 10)))
+	}
+	out, err := execInPod(kuser, ns, podName, container, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	times := map[int64]time.Time{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("Unexpected output when getting the time of the results: %s", line)
+		}
+		idx, err := strconv.ParseInt(path.Base(fields[0]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Unexpected output when getting the time of the results: %s", line)
+		}
+		epoch, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Unexpected output when getting the time of the results: %s", line)
+		}
+		times[idx] = time.Unix(epoch, 0)
+	}
+	return times, nil
+}
+
+// selectIndexes filters the available indexes according to the selection
+func selectIndexes(kuser common.KubeClientUser, ns, podName, container, resultsDir string, sel IndexSelection) ([]int64, error) {
+	available, err := listIndexes(kuser, ns, podName, container, resultsDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(available) == 0 {
+		return nil, fmt.Errorf("No results are available in the results volume")
+	}
+
+	if sel.Index != CurrentIndex {
+		for _, idx := range available {
+			if idx == sel.Index {
+				return []int64{idx}, nil
+			}
+		}
+		return nil, fmt.Errorf("Results with index %d aren't available. Available indexes: %s", sel.Index, formatIndexes(available))
+	}
+
+	if sel.Since.IsZero() {
+		return available, nil
+	}
+
+	times, err := getIndexTimes(kuser, ns, podName, container, resultsDir, available)
+	if err != nil {
+		return nil, err
+	}
+	selected := []int64{}
+	for _, idx := range available {
+		if times[idx].After(sel.Since) {
+			selected = append(selected, idx)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("No results were written after %s. Available indexes: %s", sel.Since.Format(time.RFC3339), formatIndexes(available))
+	}
+	return selected, nil
+}
+
+func formatIndexes(indexes []int64) string {
+	strs := make([]string, 0, len(indexes))
+	for _, idx := range indexes {
+		strs = append(strs, strconv.FormatInt(idx, 10))
+	}
+	return strings.Join(strs, ", ")
+}
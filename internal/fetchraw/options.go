@@ -3,7 +3,6 @@ package fetchraw
 import (
 	"fmt"
 	"os"
-	"os/exec"
 
 	"github.com/pkg/browser"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -14,9 +13,18 @@ import (
 type FetchRawOptions struct {
 	common.CommandContext
 
-	OutputPath string
-	Image      string
-	HTML       bool
+	OutputPath   string
+	Image        string
+	Transport    string
+	HTML         bool
+	HTMLRenderer string
+	Index        int64
+	AllIndexes   bool
+	Since        string
+	Parallel     common.ParallelOptions
+
+	indexes  IndexSelection
+	renderer ReportRenderer
 }
 
 func NewFetchRawOptions(streams genericclioptions.IOStreams) *FetchRawOptions {
@@ -43,25 +51,37 @@ func (o *FetchRawOptions) Validate() error {
 		return fmt.Errorf("The image parameter can't be empty")
 	}
 
+	if err := ValidateTransport(o.Transport); err != nil {
+		return err
+	}
+
 	objref, err := common.ValidateObjectArgs(o.Args)
 	if err != nil {
 		return err
 	}
 
+	if err := o.validateIndexes(); err != nil {
+		return err
+	}
+
+	if err := o.Parallel.Validate(); err != nil {
+		return err
+	}
+
 	if o.HTML {
-		_, err := exec.LookPath("oscap")
+		o.renderer, err = NewReportRenderer(o.HTMLRenderer)
 		if err != nil {
-			return fmt.Errorf("The oscap command is needed for getting the HTML output")
+			return err
 		}
 	}
 
 	switch objref.Type {
 	case common.ScanSettingBinding:
-		o.Helper = NewScanSettingBindingHelper(o.Kuser, objref.Name, o.OutputPath, o.Image, o.HTML, o.IOStreams)
+		o.Helper = NewScanSettingBindingHelper(o.Kuser, objref.Name, o.OutputPath, o.Image, o.Transport, o.renderer, o.indexes, o.Parallel, o.IOStreams)
 	case common.ComplianceSuite:
-		o.Helper = NewComplianceSuiteHelper(o.Kuser, objref.Name, o.OutputPath, o.Image, o.HTML, o.IOStreams)
+		o.Helper = NewComplianceSuiteHelper(o.Kuser, objref.Name, o.OutputPath, o.Image, o.Transport, o.renderer, o.indexes, o.Parallel, o.IOStreams)
 	case common.ComplianceScan:
-		o.Helper = NewComplianceScanHelper(o.Kuser, objref.Name, o.OutputPath, o.Image, o.HTML, o.IOStreams)
+		o.Helper = NewComplianceScanHelper(o.Kuser, objref.Name, o.OutputPath, o.Image, o.Transport, o.renderer, o.indexes, o.IOStreams)
 	default:
 		return fmt.Errorf("Invalid object type for this command")
 	}
@@ -69,6 +89,31 @@ func (o *FetchRawOptions) Validate() error {
 	return nil
 }
 
+func (o *FetchRawOptions) validateIndexes() error {
+	if o.Index < CurrentIndex {
+		return fmt.Errorf("The index can't be negative")
+	}
+	if o.Index != CurrentIndex && o.AllIndexes {
+		return fmt.Errorf("The --index and --all-indexes flags are mutually exclusive")
+	}
+	if o.Index != CurrentIndex && o.Since != "" {
+		return fmt.Errorf("The --index and --since flags are mutually exclusive")
+	}
+
+	o.indexes = IndexSelection{
+		Index: o.Index,
+		All:   o.AllIndexes,
+	}
+	if o.Since != "" {
+		since, err := ParseSince(o.Since)
+		if err != nil {
+			return err
+		}
+		o.indexes.Since = since
+	}
+	return nil
+}
+
 func (o *FetchRawOptions) Run() error {
 	if err := o.Helper.Handle(); err != nil {
 		return err
@@ -0,0 +1,68 @@
+package fetchraw
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/openshift/oc-compliance/internal/arf"
+)
+
+const (
+	// NativeRenderer renders the HTML reports without external tools
+	NativeRenderer = "native"
+	// OSCAPRenderer renders the HTML reports with the oscap command
+	OSCAPRenderer = "oscap"
+)
+
+// ReportRenderer renders an HTML report out of an ARF file
+type ReportRenderer interface {
+	Render(arfPath, reportPath string) error
+}
+
+// NewReportRenderer gets the renderer with the given name
+func NewReportRenderer(name string) (ReportRenderer, error) {
+	switch name {
+	case NativeRenderer:
+		return &nativeRenderer{}, nil
+	case OSCAPRenderer:
+		if _, err := exec.LookPath("oscap"); err != nil {
+			return nil, fmt.Errorf("The oscap command is needed for rendering the HTML output with the '%s' renderer", OSCAPRenderer)
+		}
+		return &oscapRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("Invalid HTML renderer '%s'. Must be one of: %s|%s", name, NativeRenderer, OSCAPRenderer)
+	}
+}
+
+type nativeRenderer struct{}
+
+func (r *nativeRenderer) Render(arfPath, reportPath string) error {
+	report, err := arf.ParseFile(arfPath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(reportPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := report.WriteHTML(f); err != nil {
+		return fmt.Errorf("Unable to render report %s: %s", reportPath, err)
+	}
+	return f.Sync()
+}
+
+type oscapRenderer struct{}
+
+func (r *oscapRenderer) Render(arfPath, reportPath string) error {
+	reportcmd := exec.Command("oscap", "xccdf", "generate", "report",
+		"--output", reportPath, arfPath)
+	out, err := reportcmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("Unable to render report %s: %s: %s", reportPath, err, out)
+	}
+	return nil
+}
@@ -3,6 +3,9 @@ package fetchraw
 import (
 	"context"
 	"fmt"
+	"os"
+	"path"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -18,18 +21,25 @@ type ScanSettingBindingHelper struct {
 	kind       string
 	outputPath string
 	image      string
-	html       bool
+	transport  string
+	renderer   ReportRenderer
+	indexes    IndexSelection
+	parallel   common.ParallelOptions
 	genericclioptions.IOStreams
 }
 
-func NewScanSettingBindingHelper(kuser common.KubeClientUser, name, outputPath, image string, html bool, streams genericclioptions.IOStreams) common.ObjectHelper {
+func NewScanSettingBindingHelper(kuser common.KubeClientUser, name, outputPath, image, transport string, renderer ReportRenderer, indexes IndexSelection,
+	parallel common.ParallelOptions, streams genericclioptions.IOStreams) common.ObjectHelper {
 	return &ScanSettingBindingHelper{
 		kuser:      kuser,
 		name:       name,
 		kind:       "ScanSettingBinding",
 		outputPath: outputPath,
-		html:       html,
+		renderer:   renderer,
 		image:      image,
+		transport:  transport,
+		indexes:    indexes,
+		parallel:   parallel,
 		gvk: schema.GroupVersionResource{
 			Group:    common.CmpAPIGroup,
 			Version:  common.CmpResourceVersion,
@@ -45,8 +55,31 @@ func (h *ScanSettingBindingHelper) Handle() error {
 	if err != nil {
 		return fmt.Errorf("Unable to get resource %s/%s of type %s: %s", h.kuser.GetNamespace(), h.name, h.kind, err)
 	}
-	suiteName := res.GetName()
 
-	helper := NewComplianceSuiteHelper(h.kuser, suiteName, h.outputPath, h.image, h.html, h.IOStreams)
-	return helper.Handle()
+	suiteNames, err := common.GetSuiteNamesFromBinding(h.kuser, res)
+	if err != nil {
+		return err
+	}
+
+	if len(suiteNames) == 1 {
+		helper := NewComplianceSuiteHelper(h.kuser, suiteNames[0], h.outputPath, h.image, h.transport, h.renderer, h.indexes, h.parallel, h.IOStreams)
+		return helper.Handle()
+	}
+
+	// Several suites might contain scans with the same name, so each one
+	// gets its own directory.
+	fmt.Fprintf(h.Out, "Fetching results for %s suites: %s\n", h.name, strings.Join(suiteNames, ", "))
+	// The suites are processed one at a time, as their scans already are
+	// processed in parallel
+	suiteOpts := common.ParallelOptions{Parallelism: 1, ContinueOnError: h.parallel.ContinueOnError}
+	errs := common.RunParallel(suiteNames, suiteOpts, func(suiteName string) error {
+		suiteDir := path.Join(h.outputPath, suiteName)
+		if err := os.Mkdir(suiteDir, 0700); err != nil {
+			return fmt.Errorf("Unable to create directory %s: %s", suiteDir, err)
+		}
+		helper := NewComplianceSuiteHelper(h.kuser, suiteName, suiteDir, h.image, h.transport, h.renderer, h.indexes, h.parallel, h.IOStreams)
+		return helper.Handle()
+	})
+	failure := fmt.Sprintf("Unable to fetch the results of binding %s", h.name)
+	return common.SummarizeErrors(h.Out, "ComplianceSuite", suiteNames, errs, failure)
 }
@@ -0,0 +1,204 @@
+package fetchraw
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+const (
+	// TransportAuto fetches the results from a running pod that mounts the
+	// results volume if there's one, and falls back to an extractor pod
+	TransportAuto = "auto"
+	// TransportResultServer fetches the results from a running pod that
+	// mounts the results volume, such as the operator's resultserver
+	TransportResultServer = "resultserver"
+	// TransportExec fetches the results from an extractor pod, streaming
+	// them through an exec'd tar
+	TransportExec = "exec"
+	// TransportCp fetches the results from an extractor pod with the
+	// equivalent of 'oc cp'
+	TransportCp = "cp"
+)
+
+var validTransports = []string{TransportAuto, TransportResultServer, TransportExec, TransportCp}
+
+// ValidateTransport ensures that the given transport is known
+func ValidateTransport(transport string) error {
+	for _, valid := range validTransports {
+		if transport == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid transport '%s'. Must be one of: %s", transport, strings.Join(validTransports, "|"))
+}
+
+// resultSource is a container of a running pod which mounts the raw results
+// volume
+type resultSource struct {
+	namespace string
+	pod       string
+	container string
+	// mountPath is where the results volume is mounted in the container
+	mountPath string
+}
+
+// copyFunc copies a directory of the result source into a local directory
+type copyFunc func(src resultSource, srcPath, dst string) error
+
+// findResultServer looks for a running pod of the given scan which already
+// mounts the given claim, so the results can be fetched without creating an
+// extractor pod. The operator labels the pods of a scan, such as its
+// resultserver, with the scan's name. Extractor pods are ignored, as they
+// exit once their results are fetched.
+func findResultServer(kuser common.KubeClientUser, ns, scanName, claimName string) (resultSource, error) {
+	pods, err := kuser.Clientset().CoreV1().Pods(ns).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", common.ScanNameLabel, scanName),
+	})
+	if err != nil {
+		return resultSource{}, fmt.Errorf("Unable to list pods in namespace %s: %s", ns, err)
+	}
+
+	for _, pod := range pods.Items {
+		if _, isExtractor := pod.GetLabels()[cmdLabelKey]; isExtractor {
+			continue
+		}
+		if pod.Status.Phase != corev1.PodRunning || pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+		volName := getClaimVolumeName(&pod, claimName)
+		if volName == "" {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			if !isContainerRunning(&pod, container.Name) {
+				continue
+			}
+			for _, mount := range container.VolumeMounts {
+				if mount.Name == volName && mount.SubPath == "" {
+					return resultSource{
+						namespace: ns,
+						pod:       pod.GetName(),
+						container: container.Name,
+						mountPath: mount.MountPath,
+					}, nil
+				}
+			}
+		}
+	}
+	return resultSource{}, fmt.Errorf("No running pod of scan %s mounts the PersistentVolumeClaim %s/%s", scanName, ns, claimName)
+}
+
+func getClaimVolumeName(pod *corev1.Pod, claimName string) string {
+	for _, vol := range pod.Spec.Volumes {
+		if vol.PersistentVolumeClaim != nil && vol.PersistentVolumeClaim.ClaimName == claimName {
+			return vol.Name
+		}
+	}
+	return ""
+}
+
+func isContainerRunning(pod *corev1.Pod, name string) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == name {
+			return status.State.Running != nil
+		}
+	}
+	return false
+}
+
+// copyWithTar streams the given directory of the result source through an
+// exec'd tar, and extracts it into the destination directory
+func copyWithTar(kuser common.KubeClientUser, src resultSource, srcPath, dst string) error {
+	reader, writer := io.Pipe()
+	errs := make(chan error, 1)
+	go func() {
+		cmd := []string{"tar", "cf", "-", "-C", srcPath, "."}
+		err := streamFromPod(kuser, src.namespace, src.pod, src.container, cmd, writer)
+		// Unblock the reader, reporting the error if any
+		writer.CloseWithError(err)
+		errs <- err
+	}()
+
+	extractErr := extractTar(reader, dst)
+	if extractErr == nil {
+		// tar pads the archive past its end marker, which has to be read
+		// for the exec to finish cleanly
+		_, extractErr = io.Copy(io.Discard, reader)
+	}
+	// Make sure the exec is done even if the extraction stopped early
+	reader.Close()
+	execErr := <-errs
+	// A failed extraction makes the exec fail too, so its error is the
+	// relevant one
+	if extractErr != nil {
+		return extractErr
+	}
+	return execErr
+}
+
+// extractTar extracts the directories and regular files of a tar stream into
+// the destination directory. Entries that would be written outside of it are
+// rejected, and anything else (e.g. links) is ignored.
+func extractTar(r io.Reader, dst string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("Unable to read the raw results stream: %s", err)
+		}
+
+		target, err := getExtractPath(dst, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return fmt.Errorf("Unable to create directory %s: %s", target, err)
+			}
+		case tar.TypeReg:
+			if err := writeFile(tr, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// getExtractPath resolves the path of a tar entry inside the destination
+// directory
+func getExtractPath(dst, name string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("Refusing to extract '%s' outside of %s", name, dst)
+	}
+	return filepath.Join(dst, cleaned), nil
+}
+
+func writeFile(r io.Reader, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return fmt.Errorf("Unable to create directory %s: %s", filepath.Dir(target), err)
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("Unable to create file %s: %s", target, err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("Unable to write file %s: %s", target, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,116 @@
+package fetchraw
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type tarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	content  string
+}
+
+func buildTar(t *testing.T, entries []tarEntry) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, e := range entries {
+		hdr := &tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Linkname: e.linkname,
+			Mode:     0644,
+			Size:     int64(len(e.content)),
+		}
+		if e.typeflag != tar.TypeReg {
+			hdr.Size = 0
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("Unable to write header of %s: %s", e.name, err)
+		}
+		if hdr.Size > 0 {
+			if _, err := tw.Write([]byte(e.content)); err != nil {
+				t.Fatalf("Unable to write content of %s: %s", e.name, err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Unable to close tar: %s", err)
+	}
+	return buf
+}
+
+func TestExtractTar(t *testing.T) {
+	dst := t.TempDir()
+	entries := []tarEntry{
+		{name: "./", typeflag: tar.TypeDir},
+		{name: "./scan/", typeflag: tar.TypeDir},
+		{name: "./scan/result.xml.bzip2", typeflag: tar.TypeReg, content: "arf"},
+		{name: "./top.xml", typeflag: tar.TypeReg, content: "top"},
+	}
+	if err := extractTar(buildTar(t, entries), dst); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for path, expected := range map[string]string{
+		filepath.Join(dst, "scan", "result.xml.bzip2"): "arf",
+		filepath.Join(dst, "top.xml"):                  "top",
+	} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Expected %s to be extracted: %s", path, err)
+		}
+		if string(content) != expected {
+			t.Errorf("Expected %s to contain '%s', got '%s'", path, expected, content)
+		}
+	}
+}
+
+func TestExtractTarRejectsEntriesOutsideOfDestination(t *testing.T) {
+	for _, name := range []string{
+		"../evil",
+		"./scan/../../evil",
+		"/etc/evil",
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			dst := filepath.Join(root, "dst")
+			if err := os.Mkdir(dst, 0700); err != nil {
+				t.Fatal(err)
+			}
+			entries := []tarEntry{{name: name, typeflag: tar.TypeReg, content: "evil"}}
+			if err := extractTar(buildTar(t, entries), dst); err == nil {
+				t.Fatalf("Expected '%s' to be rejected", name)
+			}
+			if _, err := os.Stat(filepath.Join(root, "evil")); !os.IsNotExist(err) {
+				t.Errorf("Expected nothing to be written outside of the destination")
+			}
+		})
+	}
+}
+
+func TestExtractTarSkipsLinks(t *testing.T) {
+	dst := t.TempDir()
+	entries := []tarEntry{
+		{name: "./symlink", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"},
+		{name: "./hardlink", typeflag: tar.TypeLink, linkname: "/etc/passwd"},
+		{name: "./result.xml", typeflag: tar.TypeReg, content: "arf"},
+	}
+	if err := extractTar(buildTar(t, entries), dst); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, name := range []string{"symlink", "hardlink"} {
+		if _, err := os.Lstat(filepath.Join(dst, name)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be skipped", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dst, "result.xml")); err != nil {
+		t.Errorf("Expected the entries after the links to be extracted: %s", err)
+	}
+}
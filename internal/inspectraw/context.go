@@ -0,0 +1,93 @@
+package inspectraw
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+	"github.com/openshift/oc-compliance/internal/rawresults"
+)
+
+// ResultListSchemaVersion is the version of the structured result list
+const ResultListSchemaVersion = "v1"
+
+// ResultList is the structured representation of the listed results
+type ResultList struct {
+	SchemaVersion string              `json:"schemaVersion"`
+	Results       []rawresults.Result `json:"results"`
+}
+
+// InspectRawContext works on fetched raw results only, so unlike other
+// commands it doesn't need access to the cluster.
+type InspectRawContext struct {
+	genericclioptions.IOStreams
+
+	Args       []string
+	Output     string
+	Results    []string
+	Severities []string
+	Filter     rawresults.Filter
+
+	dir string
+}
+
+func NewInspectRawContext(streams genericclioptions.IOStreams) *InspectRawContext {
+	return &InspectRawContext{
+		IOStreams: streams,
+	}
+}
+
+// Complete sets all information required for inspecting the results
+func (o *InspectRawContext) Complete(cmd *cobra.Command, args []string) error {
+	o.Args = args
+	return nil
+}
+
+// Validate ensures that all required arguments and flag values are provided
+func (o *InspectRawContext) Validate() error {
+	if len(o.Args) != 1 {
+		return fmt.Errorf("You need to specify exactly one directory")
+	}
+	o.dir = o.Args[0]
+
+	if err := common.ValidateDirectory(o.dir); err != nil {
+		return err
+	}
+
+	if err := common.ValidateOutputFormat(o.Output, common.OutputFormatTable, common.OutputFormatJSON, common.OutputFormatYAML); err != nil {
+		return err
+	}
+
+	o.Filter.Results = o.Results
+	o.Filter.Severities = o.Severities
+	return nil
+}
+
+func (o *InspectRawContext) Run() error {
+	set, err := rawresults.LoadDir(o.dir)
+	if err != nil {
+		return err
+	}
+	results := o.Filter.Apply(set.Results)
+
+	if o.Output != common.OutputFormatTable {
+		return common.PrintStructured(o.Out, o.Output, &ResultList{
+			SchemaVersion: ResultListSchemaVersion,
+			Results:       results,
+		})
+	}
+
+	table := tablewriter.NewWriter(o.Out)
+	table.SetHeader([]string{"Source", "Target", "Rule", "Severity", "Result"})
+	table.SetAutoWrapText(false)
+	for idx := range results {
+		res := &results[idx]
+		table.Append([]string{res.Source, res.Target, res.ShortRuleID(), res.Severity, strings.ToUpper(res.Result)})
+	}
+	table.Render()
+	return nil
+}
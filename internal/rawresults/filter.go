@@ -0,0 +1,56 @@
+package rawresults
+
+import (
+	"strings"
+)
+
+// Filter selects results. Empty fields match everything.
+type Filter struct {
+	// Results that are accepted (e.g. "fail")
+	Results []string
+	// Severities that are accepted (e.g. "high")
+	Severities []string
+	// Rule is a substring of the rule ID
+	Rule string
+	// Target is a substring of the scanned target
+	Target string
+	// Source is a substring of the directory the result was found in
+	Source string
+}
+
+// Matches tells whether the result is selected by the filter
+func (f *Filter) Matches(r *Result) bool {
+	if len(f.Results) > 0 && !containsFold(f.Results, r.Result) {
+		return false
+	}
+	if len(f.Severities) > 0 && !containsFold(f.Severities, r.Severity) {
+		return false
+	}
+	if !strings.Contains(r.RuleID, f.Rule) {
+		return false
+	}
+	if !strings.Contains(r.Target, f.Target) {
+		return false
+	}
+	return strings.Contains(r.Source, f.Source)
+}
+
+// Apply returns the results that match the filter
+func (f *Filter) Apply(results []Result) []Result {
+	out := []Result{}
+	for idx := range results {
+		if f.Matches(&results[idx]) {
+			out = append(out, results[idx])
+		}
+	}
+	return out
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
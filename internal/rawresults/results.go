@@ -0,0 +1,156 @@
+// Package rawresults provides a queryable model of the rule results found in
+// raw result files fetched with fetch-raw, which can be used without access
+// to the cluster.
+package rawresults
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openshift/oc-compliance/internal/arf"
+)
+
+const (
+	// ruleIDPrefix is the prefix of the XCCDF rule IDs of the
+	// ComplianceAsCode content
+	ruleIDPrefix = "xccdf_org.ssgproject.content_rule_"
+	// profileIDPrefix is the prefix of its XCCDF profile IDs
+	profileIDPrefix = "xccdf_org.ssgproject.content_profile_"
+)
+
+// Result is the result of a rule on a scanned target
+type Result struct {
+	// Source is the directory the result file was found in, relative to
+	// the loaded directory. e.g. the scan's directory
+	Source string `json:"source"`
+	// File is the path of the result file
+	File string `json:"file"`
+	// Profile is the XCCDF ID of the scanned profile, without the content
+	// prefix
+	Profile     string   `json:"profile,omitempty"`
+	Target      string   `json:"target"`
+	RuleID      string   `json:"ruleID"`
+	Title       string   `json:"title,omitempty"`
+	Result      string   `json:"result"`
+	Severity    string   `json:"severity"`
+	Identifiers []string `json:"identifiers,omitempty"`
+}
+
+// checkStatuses maps the XCCDF results to the ComplianceCheckResult
+// statuses, the same way the operator does
+var checkStatuses = map[string]string{
+	"pass":          "PASS",
+	"fixed":         "PASS",
+	"fail":          "FAIL",
+	"error":         "ERROR",
+	"unknown":       "ERROR",
+	"notchecked":    "MANUAL",
+	"informational": "INFO",
+	"notapplicable": "NOT-APPLICABLE",
+}
+
+// CheckStatus is the status that the operator gives to the
+// ComplianceCheckResult of this result
+func (r *Result) CheckStatus() string {
+	if status, found := checkStatuses[strings.ToLower(r.Result)]; found {
+		return status
+	}
+	return "ERROR"
+}
+
+// ShortRuleID is the rule ID without the content prefix
+func (r *Result) ShortRuleID() string {
+	return strings.TrimPrefix(r.RuleID, ruleIDPrefix)
+}
+
+// ResultSet holds the results loaded from raw result files, as well as the
+// definition of the rules they refer to
+type ResultSet struct {
+	Results []Result
+	// Rules indexed by ID
+	Rules map[string]*arf.Rule
+}
+
+// IsRawResultFile tells whether the path is named like a raw result file
+func IsRawResultFile(path string) bool {
+	return strings.HasSuffix(path, ".xml") || strings.HasSuffix(path, ".xml.bzip2")
+}
+
+// LoadDir loads the results of all the raw result files found in the given
+// directory and its subdirectories. Rules that weren't selected in the scanned
+// profile are skipped.
+func LoadDir(dir string) (*ResultSet, error) {
+	set := &ResultSet{
+		Results: []Result{},
+		Rules:   map[string]*arf.Rule{},
+	}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !IsRawResultFile(path) {
+			return nil
+		}
+		return set.loadFile(dir, path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(set.Results) == 0 {
+		return nil, fmt.Errorf("No raw results were found in %s", dir)
+	}
+
+	sort.SliceStable(set.Results, func(i, j int) bool {
+		a, b := set.Results[i], set.Results[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		return a.RuleID < b.RuleID
+	})
+	return set, nil
+}
+
+func (set *ResultSet) loadFile(root, path string) error {
+	report, err := arf.ParseFile(path)
+	if err != nil {
+		return err
+	}
+
+	source, err := filepath.Rel(root, filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	for id, rule := range report.Benchmark.Rules {
+		set.Rules[id] = rule
+	}
+
+	for _, rr := range report.TestResult.RuleResults {
+		if rr.Result == arf.NotSelectedResult {
+			continue
+		}
+		res := Result{
+			Source:   source,
+			File:     path,
+			Profile:  strings.TrimPrefix(report.TestResult.Profile.IDRef, profileIDPrefix),
+			Target:   report.TestResult.Target,
+			RuleID:   rr.IDRef,
+			Result:   rr.Result,
+			Severity: rr.Severity,
+		}
+		if rule, found := report.Benchmark.Rules[rr.IDRef]; found {
+			res.Title = rule.Title.String()
+		}
+		for _, ident := range rr.Idents {
+			res.Identifiers = append(res.Identifiers, ident.Value)
+		}
+		set.Results = append(set.Results, res)
+	}
+	return nil
+}
@@ -2,10 +2,16 @@ package rerunnow
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/openshift/oc-compliance/internal/common"
@@ -18,18 +24,20 @@ const (
 )
 
 type ComplianceScanHelper struct {
-	kuser common.KubeClientUser
-	gvk   schema.GroupVersionResource
-	kind  string
-	name  string
+	kuser  common.KubeClientUser
+	gvk    schema.GroupVersionResource
+	kind   string
+	name   string
+	waiter *ScanWaiter
 	genericclioptions.IOStreams
 }
 
-func NewComplianceScanHelper(kuser common.KubeClientUser, name string, streams genericclioptions.IOStreams) common.ObjectHelper {
+func NewComplianceScanHelper(kuser common.KubeClientUser, name string, waiter *ScanWaiter, streams genericclioptions.IOStreams) common.ObjectHelper {
 	return &ComplianceScanHelper{
-		kuser: kuser,
-		name:  name,
-		kind:  "ComplianceScan",
+		kuser:  kuser,
+		name:   name,
+		kind:   "ComplianceScan",
+		waiter: waiter,
 		gvk: schema.GroupVersionResource{
 			Group:    common.CmpAPIGroup,
 			Version:  common.CmpResourceVersion,
@@ -40,18 +48,94 @@ func NewComplianceScanHelper(kuser common.KubeClientUser, name string, streams g
 }
 
 func (h *ComplianceScanHelper) Handle() error {
-	scan, err := h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), h.name, metav1.GetOptions{})
+	var scan *unstructured.Unstructured
+	rescanned := false
+	// The operator updates the scan's status frequently, so the rescan
+	// request is retried if the scan changed since it was read
+	err := retryOnConflict(rescanBackoff, func() error {
+		var err error
+		scan, err = h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), h.name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if h.isRescanPending(scan) {
+			return nil
+		}
+		if err := h.requestRescan(scan); err != nil {
+			return err
+		}
+		rescanned = true
+		return nil
+	})
 	if err != nil {
-		return err
+		return fmt.Errorf("Unable to re-run scan %s/%s: %s", h.kuser.GetNamespace(), h.name, err)
+	}
+
+	if rescanned {
+		fmt.Fprintf(h.Out, "Re-running scan '%s/%s'\n", h.kuser.GetNamespace(), h.name)
 	}
-	annotations := scan.GetAnnotations()
-	if annotations == nil {
-		annotations = map[string]string{}
+	if h.waiter != nil {
+		h.waiter.Add(scan)
+	}
+	return nil
+}
+
+// isRescanPending tells whether the scan is already running or about to, in
+// which case another run isn't queued
+func (h *ComplianceScanHelper) isRescanPending(scan *unstructured.Unstructured) bool {
+	if _, found := scan.GetAnnotations()[rescanAnnotation]; found {
+		fmt.Fprintf(h.Out, "A re-run of scan '%s/%s' was already requested\n", h.kuser.GetNamespace(), h.name)
+		return true
+	}
+	phase, _, _ := unstructured.NestedString(scan.Object, "status", "phase")
+	if phase != phaseDone {
+		fmt.Fprintf(h.Out, "Scan '%s/%s' is already running (phase: %s), not re-running it\n", h.kuser.GetNamespace(), h.name, phase)
+		return true
+	}
+	return false
+}
+
+// requestRescan sets the rescan annotation with a merge patch. The patch
+// includes the resourceVersion of the given scan, so it fails with a
+// conflict if the scan changed (e.g. it started running) since it was read.
+func (h *ComplianceScanHelper) requestRescan(scan *unstructured.Unstructured) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": scan.GetResourceVersion(),
+			"annotations": map[string]string{
+				rescanAnnotation: "",
+			},
+		},
+	})
+	if err != nil {
+		return err
 	}
-	annotations[rescanAnnotation] = ""
-	scan.SetAnnotations(annotations)
+	_, err = h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Patch(
+		context.TODO(), h.name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// rescanBackoff is how often the rescan request is retried on conflicts
+var rescanBackoff = wait.Backoff{
+	Steps:    5,
+	Duration: 100 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
 
-	fmt.Fprintf(h.Out, "Re-running scan '%s/%s'\n", h.kuser.GetNamespace(), h.name)
-	_, err = h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Update(context.TODO(), scan, metav1.UpdateOptions{})
+// retryOnConflict runs the given function until it doesn't fail with a
+// conflict, or the backoff steps run out
+func retryOnConflict(backoff wait.Backoff, fn func() error) error {
+	var lastErr error
+	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+		lastErr = fn()
+		if kerrors.IsConflict(lastErr) {
+			return false, nil
+		}
+		return true, lastErr
+	})
+	if err == wait.ErrWaitTimeout {
+		return lastErr
+	}
 	return err
 }
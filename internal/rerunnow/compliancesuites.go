@@ -13,18 +13,23 @@ import (
 )
 
 type ComplianceSuiteHelper struct {
-	kuser common.KubeClientUser
-	gvk   schema.GroupVersionResource
-	kind  string
-	name  string
+	kuser    common.KubeClientUser
+	gvk      schema.GroupVersionResource
+	kind     string
+	name     string
+	waiter   *ScanWaiter
+	parallel common.ParallelOptions
 	genericclioptions.IOStreams
 }
 
-func NewComplianceSuiteHelper(kuser common.KubeClientUser, name string, streams genericclioptions.IOStreams) common.ObjectHelper {
+func NewComplianceSuiteHelper(kuser common.KubeClientUser, name string, waiter *ScanWaiter, parallel common.ParallelOptions,
+	streams genericclioptions.IOStreams) common.ObjectHelper {
 	return &ComplianceSuiteHelper{
-		kuser: kuser,
-		name:  name,
-		kind:  "ComplianceSuite",
+		kuser:    kuser,
+		name:     name,
+		waiter:   waiter,
+		parallel: parallel,
+		kind:     "ComplianceSuite",
 		gvk: schema.GroupVersionResource{
 			Group:    common.CmpAPIGroup,
 			Version:  common.CmpResourceVersion,
@@ -49,11 +54,14 @@ func (h *ComplianceSuiteHelper) Handle() error {
 
 	fmt.Fprintf(h.Out, "Rerunning scans from '%s': %s\n", h.name, strings.Join(scanNames, ", "))
 
-	for _, scanName := range scanNames {
-		helper := NewComplianceScanHelper(h.kuser, scanName, h.IOStreams)
-		if err = helper.Handle(); err != nil {
-			return fmt.Errorf("Unable to process results from suite %s: %s", h.name, err)
-		}
+	streams := h.IOStreams
+	if h.parallel.Parallelism > 1 {
+		streams.Out = common.NewSyncWriter(h.Out)
+		streams.ErrOut = common.NewSyncWriter(h.ErrOut)
 	}
-	return nil
+	errs := common.RunParallel(scanNames, h.parallel, func(scanName string) error {
+		return NewComplianceScanHelper(h.kuser, scanName, h.waiter, streams).Handle()
+	})
+	failure := fmt.Sprintf("Unable to re-run the scans of suite %s", h.name)
+	return common.SummarizeErrors(streams.Out, "ComplianceScan", scanNames, errs, failure)
 }
@@ -2,6 +2,7 @@ package rerunnow
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/openshift/oc-compliance/internal/common"
 	"github.com/spf13/cobra"
@@ -10,6 +11,13 @@ import (
 
 type RerunNowContext struct {
 	common.CommandContext
+
+	// Wait for the scans to finish
+	Wait     bool
+	Timeout  time.Duration
+	Parallel common.ParallelOptions
+
+	waiter *ScanWaiter
 }
 
 func NewReRunNowContext(streams genericclioptions.IOStreams) *RerunNowContext {
@@ -44,13 +52,24 @@ func (o *RerunNowContext) Validate() error {
 		return err
 	}
 
+	if err := o.Parallel.Validate(); err != nil {
+		return err
+	}
+
+	if o.Wait {
+		if o.Timeout <= 0 {
+			return fmt.Errorf("The timeout must be positive")
+		}
+		o.waiter = NewScanWaiter(o.Kuser, o.Timeout, o.IOStreams)
+	}
+
 	switch objref.Type {
 	case common.ScanSettingBinding:
-		o.Helper = NewScanSettingBindingHelper(o.Kuser, objref.Name, o.IOStreams)
+		o.Helper = NewScanSettingBindingHelper(o.Kuser, objref.Name, o.waiter, o.Parallel, o.IOStreams)
 	case common.ComplianceSuite:
-		o.Helper = NewComplianceSuiteHelper(o.Kuser, objref.Name, o.IOStreams)
+		o.Helper = NewComplianceSuiteHelper(o.Kuser, objref.Name, o.waiter, o.Parallel, o.IOStreams)
 	case common.ComplianceScan:
-		o.Helper = NewComplianceScanHelper(o.Kuser, objref.Name, o.IOStreams)
+		o.Helper = NewComplianceScanHelper(o.Kuser, objref.Name, o.waiter, o.IOStreams)
 	default:
 		return fmt.Errorf("Invalid object type for this command")
 	}
@@ -58,5 +77,22 @@ func (o *RerunNowContext) Validate() error {
 }
 
 func (o *RerunNowContext) Run() error {
-	return o.Helper.Handle()
+	err := o.Helper.Handle()
+	if err != nil && !o.Parallel.ContinueOnError {
+		return err
+	}
+	if o.waiter == nil {
+		return err
+	}
+
+	// The scans that were re-run are waited for even if others failed,
+	// but the failures take precedence when exiting
+	waitErr := o.waiter.Wait()
+	if err == nil {
+		return waitErr
+	}
+	if waitErr != nil {
+		fmt.Fprintf(o.ErrOut, "%s\n", waitErr)
+	}
+	return err
 }
@@ -12,18 +12,23 @@ import (
 )
 
 type ScanSettingBindingHelper struct {
-	kuser common.KubeClientUser
-	gvk   schema.GroupVersionResource
-	name  string
-	kind  string
+	kuser    common.KubeClientUser
+	gvk      schema.GroupVersionResource
+	name     string
+	kind     string
+	waiter   *ScanWaiter
+	parallel common.ParallelOptions
 	genericclioptions.IOStreams
 }
 
-func NewScanSettingBindingHelper(kuser common.KubeClientUser, name string, streams genericclioptions.IOStreams) common.ObjectHelper {
+func NewScanSettingBindingHelper(kuser common.KubeClientUser, name string, waiter *ScanWaiter, parallel common.ParallelOptions,
+	streams genericclioptions.IOStreams) common.ObjectHelper {
 	return &ScanSettingBindingHelper{
-		kuser: kuser,
-		name:  name,
-		kind:  "ScanSettingBinding",
+		kuser:    kuser,
+		name:     name,
+		waiter:   waiter,
+		parallel: parallel,
+		kind:     "ScanSettingBinding",
 		gvk: schema.GroupVersionResource{
 			Group:    common.CmpAPIGroup,
 			Version:  common.CmpResourceVersion,
@@ -39,8 +44,18 @@ func (h *ScanSettingBindingHelper) Handle() error {
 	if err != nil {
 		return fmt.Errorf("Unable to get resource %s/%s of type %s: %s", h.kuser.GetNamespace(), h.name, h.kind, err)
 	}
-	suiteName := res.GetName()
 
-	helper := NewComplianceSuiteHelper(h.kuser, suiteName, h.IOStreams)
-	return helper.Handle()
+	suiteNames, err := common.GetSuiteNamesFromBinding(h.kuser, res)
+	if err != nil {
+		return err
+	}
+
+	// The suites are processed one at a time, as their scans already are
+	// processed in parallel
+	suiteOpts := common.ParallelOptions{Parallelism: 1, ContinueOnError: h.parallel.ContinueOnError}
+	errs := common.RunParallel(suiteNames, suiteOpts, func(suiteName string) error {
+		return NewComplianceSuiteHelper(h.kuser, suiteName, h.waiter, h.parallel, h.IOStreams).Handle()
+	})
+	failure := fmt.Sprintf("Unable to re-run the suites of binding %s", h.name)
+	return common.SummarizeErrors(h.Out, "ComplianceSuite", suiteNames, errs, failure)
 }
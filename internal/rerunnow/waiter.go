@@ -0,0 +1,198 @@
+package rerunnow
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+const (
+	phaseDone = "DONE"
+
+	resultCompliant     = "COMPLIANT"
+	resultNonCompliant  = "NON-COMPLIANT"
+	resultNotApplicable = "NOT-APPLICABLE"
+	resultError         = "ERROR"
+	resultInconsistent  = "INCONSISTENT"
+)
+
+// scanProgress tracks a scan that was re-run
+type scanProgress struct {
+	name  string
+	suite string
+	// start of the run that was replaced, which tells an old DONE phase
+	// apart from the new one
+	previousStart string
+	phase         string
+	result        string
+	// whether the scan was seen in a phase other than DONE since it was
+	// re-run
+	restarted bool
+	done      bool
+}
+
+// ScanWaiter waits for the re-run scans to finish, printing their progress
+type ScanWaiter struct {
+	kuser   common.KubeClientUser
+	timeout time.Duration
+	scans   []*scanProgress
+	// phases of the suites that own the scans
+	suites map[string]string
+	// scans may be added concurrently
+	mu sync.Mutex
+	genericclioptions.IOStreams
+}
+
+func NewScanWaiter(kuser common.KubeClientUser, timeout time.Duration, streams genericclioptions.IOStreams) *ScanWaiter {
+	return &ScanWaiter{
+		kuser:     kuser,
+		timeout:   timeout,
+		suites:    map[string]string{},
+		IOStreams: streams,
+	}
+}
+
+// Add tracks the given scan. It must be called with the scan as it was
+// before it was re-run, or before it was found to be running already.
+func (w *ScanWaiter) Add(scan *unstructured.Unstructured) {
+	start, _, _ := unstructured.NestedString(scan.Object, "status", "startTimestamp")
+	phase, _, _ := unstructured.NestedString(scan.Object, "status", "phase")
+	suite := scan.GetLabels()[common.SuiteLabel]
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.scans = append(w.scans, &scanProgress{
+		name:          scan.GetName(),
+		suite:         suite,
+		previousStart: start,
+		phase:         phase,
+		// A scan that was already running isn't re-run, so its current
+		// run is waited for
+		restarted: phase != phaseDone,
+	})
+	if suite != "" {
+		w.suites[suite] = ""
+	}
+}
+
+// Wait waits for all the tracked scans to be DONE, and returns an error
+// whose exit code reflects their results
+func (w *ScanWaiter) Wait() error {
+	fmt.Fprintf(w.Out, "Waiting for %d scan(s) to finish\n", len(w.scans))
+	// retry and ignore errors until timeout
+	var lastErr error
+	timeouterr := wait.PollImmediate(common.RetryInterval, w.timeout, func() (bool, error) {
+		if err := w.updateSuites(); err != nil {
+			lastErr = err
+			return false, nil
+		}
+		allDone := true
+		for _, scan := range w.scans {
+			if scan.done {
+				continue
+			}
+			if err := w.updateScan(scan); err != nil {
+				lastErr = err
+				allDone = false
+				continue
+			}
+			allDone = allDone && scan.done
+		}
+		return allDone, nil
+	})
+
+	if timeouterr != nil {
+		if lastErr != nil {
+			return fmt.Errorf("The scans didn't finish before the timeout of %s: %s", w.timeout, lastErr)
+		}
+		return fmt.Errorf("The scans didn't finish before the timeout of %s", w.timeout)
+	}
+	return w.getResult()
+}
+
+func (w *ScanWaiter) updateScan(scan *scanProgress) error {
+	obj, err := w.kuser.DynamicClient().Resource(common.GVR("compliancescans")).Namespace(w.kuser.GetNamespace()).Get(
+		context.TODO(), scan.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Unable to get resource %s/%s of type %s: %s", w.kuser.GetNamespace(), scan.name, "ComplianceScan", err)
+	}
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	start, _, _ := unstructured.NestedString(obj.Object, "status", "startTimestamp")
+
+	if phase != phaseDone {
+		scan.restarted = true
+	}
+	// A new start means that the scan ran, even if it was too quick to see
+	// it running
+	if start != scan.previousStart && start != "" {
+		scan.restarted = true
+	}
+	if !scan.restarted {
+		return nil
+	}
+
+	if phase != scan.phase {
+		fmt.Fprintf(w.Out, "Scan '%s/%s' is %s\n", w.kuser.GetNamespace(), scan.name, phase)
+		scan.phase = phase
+	}
+	if phase == phaseDone {
+		scan.result, _, _ = unstructured.NestedString(obj.Object, "status", "result")
+		scan.done = true
+		fmt.Fprintf(w.Out, "Scan '%s/%s' finished with result %s\n", w.kuser.GetNamespace(), scan.name, scan.result)
+	}
+	return nil
+}
+
+func (w *ScanWaiter) updateSuites() error {
+	for suite, prevPhase := range w.suites {
+		obj, err := w.kuser.DynamicClient().Resource(common.GVR("compliancesuites")).Namespace(w.kuser.GetNamespace()).Get(
+			context.TODO(), suite, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("Unable to get resource %s/%s of type %s: %s", w.kuser.GetNamespace(), suite, "ComplianceSuite", err)
+		}
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		// The suite is still DONE until the operator picks up the re-run
+		if prevPhase == "" && phase == phaseDone {
+			continue
+		}
+		if phase != prevPhase {
+			fmt.Fprintf(w.Out, "Suite '%s/%s' is %s\n", w.kuser.GetNamespace(), suite, phase)
+			w.suites[suite] = phase
+		}
+	}
+	return nil
+}
+
+// getResult returns an error if any scan wasn't compliant. Errors take
+// precedence over non-compliance, as they mean that the actual compliance
+// is unknown.
+func (w *ScanWaiter) getResult() error {
+	nonCompliant := []string{}
+	failed := []string{}
+	for _, scan := range w.scans {
+		switch scan.result {
+		case resultCompliant, resultNotApplicable:
+			continue
+		case resultNonCompliant:
+			nonCompliant = append(nonCompliant, scan.name)
+		default:
+			failed = append(failed, scan.name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return common.NewExitCodeError(common.ExitCodeScanError, "Scans finished with an %s or %s result: %v", resultError, resultInconsistent, failed)
+	}
+	if len(nonCompliant) > 0 {
+		return common.NewExitCodeError(common.ExitCodeNonCompliant, "Scans are %s: %v", resultNonCompliant, nonCompliant)
+	}
+	fmt.Fprintf(w.Out, "All scans are %s\n", resultCompliant)
+	return nil
+}
@@ -0,0 +1,116 @@
+package scanandfetch
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+	"github.com/openshift/oc-compliance/internal/fetchraw"
+	"github.com/openshift/oc-compliance/internal/rerunnow"
+)
+
+// timestampFormat is used to name the directory of each run's results, so
+// they sort chronologically
+const timestampFormat = "20060102-150405"
+
+type ScanAndFetchContext struct {
+	common.CommandContext
+
+	OutputPath   string
+	Image        string
+	Transport    string
+	HTML         bool
+	HTMLRenderer string
+	Timeout      time.Duration
+	Parallel     common.ParallelOptions
+
+	name     string
+	renderer fetchraw.ReportRenderer
+}
+
+func NewScanAndFetchContext(streams genericclioptions.IOStreams) *ScanAndFetchContext {
+	return &ScanAndFetchContext{
+		CommandContext: common.CommandContext{
+			ConfigFlags: genericclioptions.NewConfigFlags(true),
+			IOStreams:   streams,
+		},
+	}
+}
+
+// Validate ensures that all required arguments and flag values are provided
+func (o *ScanAndFetchContext) Validate() error {
+	if len(o.Args) != 1 {
+		return fmt.Errorf("You need to specify the name of the ScanSettingBinding to scan")
+	}
+	o.name = o.Args[0]
+
+	if err := common.ValidateDirectory(o.OutputPath); err != nil {
+		return err
+	}
+
+	if o.Image == "" {
+		return fmt.Errorf("The image parameter can't be empty")
+	}
+
+	if err := fetchraw.ValidateTransport(o.Transport); err != nil {
+		return err
+	}
+
+	if err := o.Parallel.Validate(); err != nil {
+		return err
+	}
+
+	if o.Timeout <= 0 {
+		return fmt.Errorf("The timeout must be positive")
+	}
+
+	if o.HTML {
+		renderer, err := fetchraw.NewReportRenderer(o.HTMLRenderer)
+		if err != nil {
+			return err
+		}
+		o.renderer = renderer
+	}
+	return nil
+}
+
+// Run re-runs the scans of the binding, waits for them to finish and fetches
+// their results. Non-compliant results don't prevent the results from being
+// fetched, but they're still reported through the exit code. With
+// ContinueOnError, scans that couldn't be re-run don't prevent the rest from
+// being waited for and fetched either.
+func (o *ScanAndFetchContext) Run() error {
+	waiter := rerunnow.NewScanWaiter(o.Kuser, o.Timeout, o.IOStreams)
+	rerunErr := rerunnow.NewScanSettingBindingHelper(o.Kuser, o.name, waiter, o.Parallel, o.IOStreams).Handle()
+	if rerunErr != nil && !o.Parallel.ContinueOnError {
+		return rerunErr
+	}
+
+	resultErr := waiter.Wait()
+	var exitErr *common.ExitCodeError
+	if resultErr != nil && !errors.As(resultErr, &exitErr) {
+		return resultErr
+	}
+
+	outputPath := path.Join(o.OutputPath, fmt.Sprintf("%s-%s", o.name, time.Now().UTC().Format(timestampFormat)))
+	if err := os.Mkdir(outputPath, 0700); err != nil {
+		return fmt.Errorf("Unable to create directory %s: %s", outputPath, err)
+	}
+	indexes := fetchraw.IndexSelection{Index: fetchraw.CurrentIndex}
+	helper := fetchraw.NewScanSettingBindingHelper(o.Kuser, o.name, outputPath, o.Image, o.Transport, o.renderer, indexes, o.Parallel, o.IOStreams)
+	if err := helper.Handle(); err != nil {
+		return err
+	}
+	fmt.Fprintf(o.Out, "The results of '%s' were stored in %s\n", o.name, outputPath)
+
+	// Scans that couldn't be re-run take precedence over the results
+	if rerunErr != nil {
+		return rerunErr
+	}
+	return resultErr
+}
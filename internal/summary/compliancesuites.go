@@ -0,0 +1,54 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+type ComplianceSuiteHelper struct {
+	kuser   common.KubeClientUser
+	gvk     schema.GroupVersionResource
+	kind    string
+	name    string
+	summary *Summary
+	genericclioptions.IOStreams
+}
+
+func NewComplianceSuiteHelper(kuser common.KubeClientUser, name string, summary *Summary, streams genericclioptions.IOStreams) common.ObjectHelper {
+	return &ComplianceSuiteHelper{
+		kuser:     kuser,
+		name:      name,
+		kind:      "ComplianceSuite",
+		summary:   summary,
+		gvk:       common.GVR("compliancesuites"),
+		IOStreams: streams,
+	}
+}
+
+func (h *ComplianceSuiteHelper) Handle() error {
+	// Get target resource
+	res, err := h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), h.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Unable to get resource %s/%s of type %s: %s", h.kuser.GetNamespace(), h.name, h.kind, err)
+	}
+
+	// Get needed data
+	scanNames, err := common.GetScanNamesFromSuite(res)
+	if err != nil {
+		return err
+	}
+
+	for _, scanName := range scanNames {
+		helper := NewComplianceScanHelper(h.kuser, scanName, h.summary, h.IOStreams)
+		if err = helper.Handle(); err != nil {
+			return fmt.Errorf("Unable to process results from suite %s: %s", h.name, err)
+		}
+	}
+	return nil
+}
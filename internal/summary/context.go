@@ -0,0 +1,82 @@
+package summary
+
+import (
+	"fmt"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+// NoFailThreshold disables the check on the number of failed results
+const NoFailThreshold = -1
+
+type SummaryContext struct {
+	common.CommandContext
+
+	Output        string
+	FailThreshold int
+
+	summary *Summary
+}
+
+func NewSummaryContext(streams genericclioptions.IOStreams) *SummaryContext {
+	return &SummaryContext{
+		CommandContext: common.CommandContext{
+			ConfigFlags: genericclioptions.NewConfigFlags(true),
+			IOStreams:   streams,
+		},
+	}
+}
+
+// Validate ensures that all required arguments and flag values are provided
+func (o *SummaryContext) Validate() error {
+	err := common.ValidateOutputFormat(o.Output, common.OutputFormatTable, common.OutputFormatJSON, common.OutputFormatYAML)
+	if err != nil {
+		return err
+	}
+
+	if o.FailThreshold < NoFailThreshold {
+		return fmt.Errorf("The fail threshold can't be lower than %d", NoFailThreshold)
+	}
+
+	objref, err := common.ValidateObjectArgs(o.Args)
+	if err != nil {
+		return err
+	}
+
+	switch objref.Type {
+	case common.ScanSettingBinding:
+		o.summary = NewSummary("ScanSettingBinding", objref.Name)
+		o.Helper = NewScanSettingBindingHelper(o.Kuser, objref.Name, o.summary, o.IOStreams)
+	case common.ComplianceSuite:
+		o.summary = NewSummary("ComplianceSuite", objref.Name)
+		o.Helper = NewComplianceSuiteHelper(o.Kuser, objref.Name, o.summary, o.IOStreams)
+	case common.ComplianceScan:
+		o.summary = NewSummary("ComplianceScan", objref.Name)
+		o.Helper = NewComplianceScanHelper(o.Kuser, objref.Name, o.summary, o.IOStreams)
+	default:
+		return fmt.Errorf("Invalid object type for this command")
+	}
+	return nil
+}
+
+func (o *SummaryContext) Run() error {
+	if err := o.Helper.Handle(); err != nil {
+		return err
+	}
+
+	if o.Output != common.OutputFormatTable {
+		if err := common.PrintStructured(o.Out, o.Output, o.summary); err != nil {
+			return err
+		}
+	} else {
+		o.summary.render(o.Out)
+	}
+
+	failed := o.summary.Status["FAIL"]
+	if o.FailThreshold != NoFailThreshold && failed > o.FailThreshold {
+		return fmt.Errorf("%d checks failed, which exceeds the threshold of %d", failed, o.FailThreshold)
+	}
+	return nil
+}
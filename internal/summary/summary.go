@@ -0,0 +1,175 @@
+package summary
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// SummarySchemaVersion is the version of the structured Summary output
+const SummarySchemaVersion = "v1"
+
+const unknownValue = "unknown"
+
+// The order in which the well-known statuses and severities are displayed.
+// Anything else is displayed afterwards in alphabetical order.
+var (
+	knownStatuses   = []string{"PASS", "FAIL", "MANUAL", "INCONSISTENT", "NOT-APPLICABLE", "INFO", "ERROR"}
+	knownSeverities = []string{"high", "medium", "low", "info", unknownValue}
+)
+
+// ResultCounts holds the number of ComplianceCheckResults per status, and per
+// severity and status
+type ResultCounts struct {
+	Total    int                       `json:"total"`
+	Status   map[string]int            `json:"status"`
+	Severity map[string]map[string]int `json:"severity"`
+}
+
+func newResultCounts() ResultCounts {
+	return ResultCounts{
+		Status:   map[string]int{},
+		Severity: map[string]map[string]int{},
+	}
+}
+
+func (c *ResultCounts) add(status, severity string) {
+	c.Total++
+	c.Status[status]++
+	if _, found := c.Severity[severity]; !found {
+		c.Severity[severity] = map[string]int{}
+	}
+	c.Severity[severity][status]++
+}
+
+// ScanSummary holds the result counts of a ComplianceScan
+type ScanSummary struct {
+	Name string `json:"name"`
+	ResultCounts
+}
+
+// Summary holds the result counts of all the scans related to an object, as
+// well as the totals across them
+type Summary struct {
+	SchemaVersion string `json:"schemaVersion"`
+	Kind          string `json:"kind"`
+	Name          string `json:"name"`
+	ResultCounts
+	Scans []*ScanSummary `json:"scans"`
+}
+
+func NewSummary(kind, name string) *Summary {
+	return &Summary{
+		SchemaVersion: SummarySchemaVersion,
+		Kind:          kind,
+		Name:          name,
+		ResultCounts:  newResultCounts(),
+		Scans:         []*ScanSummary{},
+	}
+}
+
+// AddScan adds the given results to the summary under the given scan name
+func (s *Summary) AddScan(scanName string, results []unstructured.Unstructured) error {
+	scan := &ScanSummary{
+		Name:         scanName,
+		ResultCounts: newResultCounts(),
+	}
+	for idx := range results {
+		res := &results[idx]
+		status, found, err := unstructured.NestedString(res.Object, "status")
+		if err != nil {
+			return fmt.Errorf("Unable to get status of %s/%s of type %s: %s", res.GetNamespace(), res.GetName(), res.GetKind(), err)
+		}
+		if !found {
+			status = unknownValue
+		}
+		severity, found, err := unstructured.NestedString(res.Object, "severity")
+		if err != nil {
+			return fmt.Errorf("Unable to get severity of %s/%s of type %s: %s", res.GetNamespace(), res.GetName(), res.GetKind(), err)
+		}
+		if !found {
+			severity = unknownValue
+		}
+		scan.add(status, severity)
+		s.add(status, severity)
+	}
+	s.Scans = append(s.Scans, scan)
+	return nil
+}
+
+func (s *Summary) render(w io.Writer) {
+	statuses := sortKeys(mapKeys(s.Status), knownStatuses)
+
+	fmt.Fprintf(w, "Results per scan:\n")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(append(append([]string{"Scan"}, statuses...), "Total"))
+	for _, scan := range s.Scans {
+		table.Append(countsRow(scan.Name, scan.Status, scan.Total, statuses))
+	}
+	table.SetFooter(countsRow("Total", s.Status, s.Total, statuses))
+	table.Render()
+
+	fmt.Fprintf(w, "\nResults per severity:\n")
+	table = tablewriter.NewWriter(w)
+	table.SetHeader(append(append([]string{"Severity"}, statuses...), "Total"))
+	severities := make([]string, 0, len(s.Severity))
+	for severity := range s.Severity {
+		severities = append(severities, severity)
+	}
+	for _, severity := range sortKeys(severities, knownSeverities) {
+		counts := s.Severity[severity]
+		total := 0
+		for _, count := range counts {
+			total += count
+		}
+		table.Append(countsRow(severity, counts, total, statuses))
+	}
+	table.Render()
+}
+
+func countsRow(name string, counts map[string]int, total int, statuses []string) []string {
+	row := []string{name}
+	for _, status := range statuses {
+		row = append(row, strconv.Itoa(counts[status]))
+	}
+	return append(row, strconv.Itoa(total))
+}
+
+func mapKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// sortKeys returns the well-known keys first, in the given order, followed by
+// any other of the given keys in alphabetical order. The well-known keys are
+// always returned (except for "unknown") so the tables keep the same shape
+// between runs.
+func sortKeys(keys []string, known []string) []string {
+	out := []string{}
+	present := map[string]bool{}
+	for _, k := range keys {
+		present[k] = true
+	}
+	isKnown := map[string]bool{}
+	for _, k := range known {
+		isKnown[k] = true
+		if present[k] || k != unknownValue {
+			out = append(out, k)
+		}
+	}
+	extra := []string{}
+	for _, k := range keys {
+		if !isKnown[k] {
+			extra = append(extra, k)
+		}
+	}
+	sort.Strings(extra)
+	return append(out, extra...)
+}
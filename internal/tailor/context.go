@@ -0,0 +1,96 @@
+package tailor
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+type TailorContext struct {
+	common.CommandContext
+
+	Extends      string
+	EnableRules  []string
+	DisableRules []string
+	SetVars      []string
+	Rationale    string
+	Title        string
+	Description  string
+	DryRun       bool
+
+	builder *TailoredProfileBuilder
+}
+
+func NewTailorContext(streams genericclioptions.IOStreams) *TailorContext {
+	return &TailorContext{
+		CommandContext: common.CommandContext{
+			ConfigFlags: genericclioptions.NewConfigFlags(true),
+			IOStreams:   streams,
+		},
+	}
+}
+
+// Validate ensures that all required arguments and flag values are provided
+func (o *TailorContext) Validate() error {
+	if len(o.Args) != 1 {
+		return fmt.Errorf("You need to specify the name of the TailoredProfile to create")
+	}
+
+	if o.Extends == "" {
+		return fmt.Errorf("The extends parameter is required")
+	}
+	extends, err := parseExtends(o.Extends)
+	if err != nil {
+		return err
+	}
+
+	if len(o.EnableRules)+len(o.DisableRules)+len(o.SetVars) == 0 {
+		return fmt.Errorf("You need to enable or disable at least one rule, or set at least one variable")
+	}
+
+	o.builder = NewTailoredProfileBuilder(o.Args[0], extends)
+	o.builder.Title = o.Title
+	o.builder.Description = o.Description
+	for _, rule := range o.EnableRules {
+		o.builder.EnableRule(rule, o.Rationale)
+	}
+	for _, rule := range o.DisableRules {
+		o.builder.DisableRule(rule, o.Rationale)
+	}
+	for _, setVar := range o.SetVars {
+		parts := strings.SplitN(setVar, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("Malformed variable setting '%s'. Must be <variable>=<value>", setVar)
+		}
+		o.builder.SetValue(parts[0], parts[1], o.Rationale)
+	}
+
+	return o.builder.Validate(common.NewObjectCache(o.Kuser))
+}
+
+// parseExtends gets the name of the extended Profile, which may be given as
+// "<name>" or "profile/<name>"
+func parseExtends(extends string) (string, error) {
+	if !strings.Contains(extends, "/") {
+		return extends, nil
+	}
+	ref, err := common.ValidateObjectArgs([]string{extends})
+	if err != nil {
+		return "", err
+	}
+	if ref.Type != common.Profile {
+		return "", fmt.Errorf("Invalid type. A TailoredProfile can only extend a Profile.")
+	}
+	return ref.Name, nil
+}
+
+func (o *TailorContext) Run() error {
+	tp, err := o.builder.Build()
+	if err != nil {
+		return err
+	}
+	return CreateOrPrint(o.Kuser, tp, o.DryRun, o.Out)
+}
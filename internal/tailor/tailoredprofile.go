@@ -0,0 +1,364 @@
+package tailor
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sserial "k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+// RuleSelection is a rule that a TailoredProfile enables or disables
+type RuleSelection struct {
+	Name      string
+	Rationale string
+}
+
+// ValueSetting is a variable that a TailoredProfile sets
+type ValueSetting struct {
+	Name      string
+	Value     string
+	Rationale string
+}
+
+// TailoredProfileBuilder builds a TailoredProfile that extends a Profile, or
+// that selects all its rules if Extends is empty
+type TailoredProfileBuilder struct {
+	Name        string
+	Extends     string
+	Title       string
+	Description string
+	// ProductType is only needed when no Profile is extended, as it's
+	// taken from the extended Profile otherwise
+	ProductType string
+
+	EnableRules  []RuleSelection
+	DisableRules []RuleSelection
+	ManualRules  []RuleSelection
+	SetValues    []ValueSetting
+}
+
+func NewTailoredProfileBuilder(name, extends string) *TailoredProfileBuilder {
+	return &TailoredProfileBuilder{
+		Name:    name,
+		Extends: extends,
+	}
+}
+
+// NewTailoredProfileBuilderFrom creates a builder for a new TailoredProfile
+// that extends the given Profile. If a TailoredProfile is given instead, the
+// new one extends the same Profile, if any, and keeps its selections.
+func NewTailoredProfileBuilderFrom(name string, prof *unstructured.Unstructured) (*TailoredProfileBuilder, error) {
+	if prof.GetKind() == "Profile" {
+		return NewTailoredProfileBuilder(name, prof.GetName()), nil
+	}
+
+	extends, err := common.GetProfileFromTailoredProfile(prof)
+	if err != nil {
+		return nil, err
+	}
+	b := NewTailoredProfileBuilder(name, extends)
+	if extends == "" {
+		b.ProductType = prof.GetAnnotations()[common.ProductTypeAnnotation]
+	}
+	b.Title, _, _ = unstructured.NestedString(prof.Object, "spec", "title")
+	b.Description, _, _ = unstructured.NestedString(prof.Object, "spec", "description")
+	if b.EnableRules, err = getRuleSelections(prof, "enableRules"); err != nil {
+		return nil, err
+	}
+	if b.DisableRules, err = getRuleSelections(prof, "disableRules"); err != nil {
+		return nil, err
+	}
+	if b.ManualRules, err = getRuleSelections(prof, "manualRules"); err != nil {
+		return nil, err
+	}
+
+	values, _, err := unstructured.NestedSlice(prof.Object, "spec", "setValues")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get setValues of %s/%s of type %s: %s", prof.GetNamespace(), prof.GetName(), prof.GetKind(), err)
+	}
+	for _, rawval := range values {
+		val, ok := rawval.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Unable to parse setValues of %s/%s of type %s", prof.GetNamespace(), prof.GetName(), prof.GetKind())
+		}
+		name, _, _ := unstructured.NestedString(val, "name")
+		value, _, _ := unstructured.NestedString(val, "value")
+		rationale, _, _ := unstructured.NestedString(val, "rationale")
+		b.SetValue(name, value, rationale)
+	}
+	return b, nil
+}
+
+func getRuleSelections(prof *unstructured.Unstructured, field string) ([]RuleSelection, error) {
+	rawsels, _, err := unstructured.NestedSlice(prof.Object, "spec", field)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get %s of %s/%s of type %s: %s", field, prof.GetNamespace(), prof.GetName(), prof.GetKind(), err)
+	}
+	sels := []RuleSelection{}
+	for _, rawsel := range rawsels {
+		sel, ok := rawsel.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Unable to parse %s of %s/%s of type %s", field, prof.GetNamespace(), prof.GetName(), prof.GetKind())
+		}
+		name, _, _ := unstructured.NestedString(sel, "name")
+		rationale, _, _ := unstructured.NestedString(sel, "rationale")
+		sels = append(sels, RuleSelection{name, rationale})
+	}
+	return sels, nil
+}
+
+func (b *TailoredProfileBuilder) EnableRule(name, rationale string) {
+	b.EnableRules = append(b.EnableRules, RuleSelection{name, rationale})
+}
+
+func (b *TailoredProfileBuilder) DisableRule(name, rationale string) {
+	b.DisableRules = append(b.DisableRules, RuleSelection{name, rationale})
+}
+
+// ExcludeRule makes sure that the given rule isn't checked. If the rule is
+// enabled or manual, it's no longer so. If the extended Profile also includes
+// it, or it wasn't selected by the builder at all, it's disabled.
+func (b *TailoredProfileBuilder) ExcludeRule(cache *common.ObjectCache, name, rationale string) error {
+	selected := false
+	for idx, sel := range b.EnableRules {
+		if sel.Name == name {
+			b.EnableRules = append(b.EnableRules[:idx], b.EnableRules[idx+1:]...)
+			selected = true
+			break
+		}
+	}
+	for idx, sel := range b.ManualRules {
+		if sel.Name == name {
+			b.ManualRules = append(b.ManualRules[:idx], b.ManualRules[idx+1:]...)
+			selected = true
+			break
+		}
+	}
+	for _, sel := range b.DisableRules {
+		if sel.Name == name {
+			return nil
+		}
+	}
+
+	if selected {
+		inherited, err := b.isInherited(cache, name)
+		if err != nil || !inherited {
+			return err
+		}
+	}
+	b.DisableRule(name, rationale)
+	return nil
+}
+
+// isInherited tells whether the extended Profile includes the given rule
+func (b *TailoredProfileBuilder) isInherited(cache *common.ObjectCache, name string) (bool, error) {
+	if b.Extends == "" {
+		return false, nil
+	}
+	prof, err := cache.Get(common.GVR("profiles"), b.Extends)
+	if kerrors.IsNotFound(err) {
+		return false, fmt.Errorf("The Profile '%s' doesn't exist", b.Extends)
+	} else if err != nil {
+		return false, fmt.Errorf("Unable to get resource %s of type %s: %s", b.Extends, "Profile", err)
+	}
+	rules, err := common.GetRulesFromProfile(prof)
+	if err != nil {
+		return false, err
+	}
+	for _, rule := range rules {
+		if rule == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (b *TailoredProfileBuilder) SetValue(name, value, rationale string) {
+	b.SetValues = append(b.SetValues, ValueSetting{name, value, rationale})
+}
+
+// Validate verifies that the extended Profile and the referenced rules and
+// variables exist, and that they come from the same ProfileBundle. If no
+// title or description were given, they're derived from the extended Profile.
+func (b *TailoredProfileBuilder) Validate(cache *common.ObjectCache) error {
+	if b.Extends == "" {
+		return b.validateWithoutParent(cache)
+	}
+
+	prof, err := cache.Get(common.GVR("profiles"), b.Extends)
+	if kerrors.IsNotFound(err) {
+		return fmt.Errorf("The Profile '%s' doesn't exist", b.Extends)
+	} else if err != nil {
+		return fmt.Errorf("Unable to get resource %s of type %s: %s", b.Extends, "Profile", err)
+	}
+
+	if b.Title == "" {
+		title, _, _ := unstructured.NestedString(prof.Object, "title")
+		b.Title = fmt.Sprintf("%s (tailored)", title)
+	}
+	if b.Description == "" {
+		b.Description = fmt.Sprintf("Tailored version of the %s profile", b.Extends)
+	}
+	return b.validateReferences(cache, prof.GetLabels()[common.ProfileBundleLabel])
+}
+
+// validateWithoutParent validates a TailoredProfile that doesn't extend any
+// profile, whose bundle is the one of its rules
+func (b *TailoredProfileBuilder) validateWithoutParent(cache *common.ObjectCache) error {
+	selected := append(append([]RuleSelection{}, b.EnableRules...), b.ManualRules...)
+	if len(selected) == 0 {
+		return fmt.Errorf("The TailoredProfile '%s' doesn't extend a Profile, so it must enable some rules", b.Name)
+	}
+	if b.ProductType == "" {
+		return fmt.Errorf("The TailoredProfile '%s' doesn't extend a Profile, so it must have a product type", b.Name)
+	}
+	if b.Title == "" {
+		b.Title = b.Name
+	}
+	if b.Description == "" {
+		b.Description = fmt.Sprintf("The %s TailoredProfile", b.Name)
+	}
+
+	rule, err := cache.Get(common.GVR("rules"), selected[0].Name)
+	if kerrors.IsNotFound(err) {
+		return fmt.Errorf("The Rule '%s' doesn't exist", selected[0].Name)
+	} else if err != nil {
+		return fmt.Errorf("Unable to get resource %s of type %s: %s", selected[0].Name, "Rule", err)
+	}
+	return b.validateReferences(cache, rule.GetLabels()[common.ProfileBundleLabel])
+}
+
+// validateReferences verifies that the selected rules and variables exist in
+// the given ProfileBundle
+func (b *TailoredProfileBuilder) validateReferences(cache *common.ObjectCache, bundle string) error {
+
+	if err := cache.PrefetchRules(bundle); err != nil {
+		return err
+	}
+	selector := ""
+	if bundle != "" {
+		selector = fmt.Sprintf("%s=%s", common.ProfileBundleLabel, bundle)
+	}
+	if err := cache.Prefetch(common.GVR("variables"), selector); err != nil {
+		return err
+	}
+
+	errs := []error{}
+	seen := map[string]bool{}
+	for _, sel := range append(append(append([]RuleSelection{}, b.EnableRules...), b.DisableRules...), b.ManualRules...) {
+		if seen[sel.Name] {
+			errs = append(errs, fmt.Errorf("The rule '%s' is selected more than once", sel.Name))
+			continue
+		}
+		seen[sel.Name] = true
+		errs = append(errs, checkReference(cache, common.GVR("rules"), "Rule", sel.Name, bundle))
+	}
+	seen = map[string]bool{}
+	for _, val := range b.SetValues {
+		if seen[val.Name] {
+			errs = append(errs, fmt.Errorf("The variable '%s' is set more than once", val.Name))
+			continue
+		}
+		seen[val.Name] = true
+		errs = append(errs, checkReference(cache, common.GVR("variables"), "Variable", val.Name, bundle))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// checkReference verifies that the given object exists and belongs to the
+// given ProfileBundle
+func checkReference(cache *common.ObjectCache, gvr schema.GroupVersionResource, kind, name, bundle string) error {
+	obj, err := cache.Get(gvr, name)
+	if kerrors.IsNotFound(err) {
+		return fmt.Errorf("The %s '%s' doesn't exist", kind, name)
+	} else if err != nil {
+		return fmt.Errorf("Unable to get resource %s of type %s: %s", name, kind, err)
+	}
+	objBundle := obj.GetLabels()[common.ProfileBundleLabel]
+	if bundle != "" && objBundle != "" && objBundle != bundle {
+		return fmt.Errorf("The %s '%s' belongs to the ProfileBundle '%s', but the TailoredProfile's content comes from '%s'",
+			kind, name, objBundle, bundle)
+	}
+	return nil
+}
+
+// Build creates the TailoredProfile object
+func (b *TailoredProfileBuilder) Build() (*unstructured.Unstructured, error) {
+	tp := &unstructured.Unstructured{}
+	tpRaw := tp.UnstructuredContent()
+	tp.SetUnstructuredContent(tpRaw)
+	tp.SetName(b.Name)
+	tp.SetGroupVersionKind(
+		schema.GroupVersionKind{
+			Group:   common.CmpAPIGroup,
+			Version: common.CmpResourceVersion,
+			Kind:    "TailoredProfile",
+		})
+
+	spec := map[string]interface{}{
+		"title":       b.Title,
+		"description": b.Description,
+	}
+	if b.Extends != "" {
+		spec["extends"] = b.Extends
+	} else {
+		tp.SetAnnotations(map[string]string{common.ProductTypeAnnotation: b.ProductType})
+	}
+	if len(b.EnableRules) > 0 {
+		spec["enableRules"] = ruleSelectionsToSlice(b.EnableRules)
+	}
+	if len(b.DisableRules) > 0 {
+		spec["disableRules"] = ruleSelectionsToSlice(b.DisableRules)
+	}
+	if len(b.ManualRules) > 0 {
+		spec["manualRules"] = ruleSelectionsToSlice(b.ManualRules)
+	}
+	if len(b.SetValues) > 0 {
+		values := []interface{}{}
+		for _, val := range b.SetValues {
+			values = append(values, map[string]interface{}{
+				"name":      val.Name,
+				"value":     val.Value,
+				"rationale": val.Rationale,
+			})
+		}
+		spec["setValues"] = values
+	}
+	if err := unstructured.SetNestedMap(tpRaw, spec, "spec"); err != nil {
+		return nil, err
+	}
+	return tp, nil
+}
+
+func ruleSelectionsToSlice(sels []RuleSelection) []interface{} {
+	out := []interface{}{}
+	for _, sel := range sels {
+		out = append(out, map[string]interface{}{
+			"name":      sel.Name,
+			"rationale": sel.Rationale,
+		})
+	}
+	return out
+}
+
+// CreateOrPrint creates the given TailoredProfile, or prints it as YAML if
+// dryRun is set
+func CreateOrPrint(kuser common.KubeClientUser, tp *unstructured.Unstructured, dryRun bool, w io.Writer) error {
+	if dryRun {
+		yamlSerializer := k8sserial.NewYAMLSerializer(k8sserial.DefaultMetaFactory, nil, nil)
+		return common.PersistObjectToYaml(tp.GetName(), tp, w, yamlSerializer)
+	}
+
+	fmt.Fprintf(w, "Creating TailoredProfile %s\n", tp.GetName())
+	_, err := kuser.DynamicClient().Resource(common.GVR("tailoredprofiles")).Namespace(kuser.GetNamespace()).Create(
+		context.TODO(), tp, metav1.CreateOptions{})
+	return err
+}
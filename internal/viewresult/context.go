@@ -9,6 +9,8 @@ import (
 
 type ViewResultContext struct {
 	common.CommandContext
+	Output   string
+	Selector string
 }
 
 func NewViewResultContext(streams genericclioptions.IOStreams) *ViewResultContext {
@@ -22,11 +24,16 @@ func NewViewResultContext(streams genericclioptions.IOStreams) *ViewResultContex
 
 // Validate ensures that all required arguments and flag values are provided
 func (o *ViewResultContext) Validate() error {
-	if len(o.Args) < 1 {
-		return fmt.Errorf("You need to select at least one result")
+	if len(o.Args) < 1 && o.Selector == "" {
+		return fmt.Errorf("You need to select at least one result by name or label selector")
 	}
 
-	o.Helper = NewResultHelper(o.Kuser, o.Args[0], o.IOStreams)
+	err := common.ValidateOutputFormat(o.Output, common.OutputFormatTable, common.OutputFormatJSON, common.OutputFormatYAML)
+	if err != nil {
+		return err
+	}
+
+	o.Helper = NewResultHelper(o.Kuser, o.Args, o.Selector, o.Output, o.IOStreams)
 	return nil
 }
 
@@ -0,0 +1,105 @@
+package viewresult
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sserial "k8s.io/apimachinery/pkg/runtime/serializer/json"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+// ResultReportSchemaVersion is the version of the structured (JSON/YAML)
+// representation of a ResultReport. It must be bumped whenever a field is
+// removed or changes meaning. The structured output of the other commands is
+// versioned the same way.
+const ResultReportSchemaVersion = "v1"
+
+// ResultReport contains all the information gathered about a
+// ComplianceCheckResult from the result itself, its Rule and its
+// ComplianceRemediation.
+type ResultReport struct {
+	SchemaVersion  string                   `json:"schemaVersion"`
+	ResultName     string                   `json:"resultName"`
+	RuleName       string                   `json:"ruleName"`
+	Title          string                   `json:"title"`
+	Status         string                   `json:"status"`
+	Severity       string                   `json:"severity"`
+	Description    string                   `json:"description"`
+	Rationale      string                   `json:"rationale"`
+	Instructions   string                   `json:"instructions,omitempty"`
+	Controls       map[string][]string      `json:"controls,omitempty"`
+	AvailableFixes []map[string]interface{} `json:"availableFixes,omitempty"`
+	Remediation    *RemediationReport       `json:"remediation,omitempty"`
+}
+
+// ResultReportList is the structured representation used when several
+// results are viewed at once
+type ResultReportList struct {
+	SchemaVersion string          `json:"schemaVersion"`
+	Items         []*ResultReport `json:"items"`
+}
+
+// RemediationReport describes the ComplianceRemediation created for a result
+type RemediationReport struct {
+	Name             string `json:"name"`
+	ApplicationState string `json:"applicationState"`
+}
+
+func (r *ResultReport) render(w io.Writer) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Key", "Value"})
+	table.SetAutoWrapText(true)
+	table.SetReflowDuringAutoWrap(false)
+	table.SetAutoMergeCells(true)
+	table.SetRowLine(true)
+
+	table.Append([]string{"Title", r.Title})
+	table.Append([]string{"Status", r.Status})
+	table.Append([]string{"Severity", r.Severity})
+	table.Append([]string{"Description", r.Description})
+	table.Append([]string{"Rationale", r.Rationale})
+	if r.Instructions != "" {
+		table.Append([]string{"Instructions", r.Instructions})
+	}
+
+	benchmarks := make([]string, 0, len(r.Controls))
+	for benchmark := range r.Controls {
+		benchmarks = append(benchmarks, benchmark)
+	}
+	sort.Strings(benchmarks)
+	for _, benchmark := range benchmarks {
+		bmtext := fmt.Sprintf("%s Controls", benchmark)
+		table.Append([]string{bmtext, strings.Join(r.Controls[benchmark], ", ")})
+	}
+
+	if len(r.AvailableFixes) > 0 {
+		table.Append([]string{"Available Fix", "Yes"})
+		yamlSerializer := k8sserial.NewYAMLSerializer(k8sserial.DefaultMetaFactory, nil, nil)
+		for _, fix := range r.AvailableFixes {
+			objToPersist := &unstructured.Unstructured{Object: fix}
+			buf := bytes.Buffer{}
+			common.PersistObjectToYaml("", objToPersist, &buf, yamlSerializer)
+			table.Append([]string{"Fix Object", buf.String()})
+		}
+	} else {
+		table.Append([]string{"Available Fix", "No"})
+	}
+
+	table.Append([]string{"Result Object Name", r.ResultName})
+	table.Append([]string{"Rule Object Name", r.RuleName})
+
+	if r.Remediation != nil {
+		table.Append([]string{"Remediation Created", "Yes"})
+		table.Append([]string{"Remediation Name", r.Remediation.Name})
+		table.Append([]string{"Remediation Status", r.Remediation.ApplicationState})
+	} else {
+		table.Append([]string{"Remediation Created", "No"})
+	}
+	table.Render()
+}
@@ -1,10 +1,8 @@
 package viewresult
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"strings"
 
 	goerrors "github.com/pkg/errors"
 
@@ -12,494 +10,231 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	k8sserial "k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
-	"github.com/olekukonko/tablewriter"
 	"github.com/openshift/oc-compliance/internal/common"
 )
 
-const (
-	ruleAnnotationKey       = "compliance.openshift.io/rule"
-	controlAnnotationPrefix = "control.compliance.openshift.io/"
-)
-
 type ResultHelper struct {
-	kuser common.KubeClientUser
-	gvk   schema.GroupVersionResource
-	kind  string
-	name  string
+	kuser    common.KubeClientUser
+	gvk      schema.GroupVersionResource
+	kind     string
+	names    []string
+	selector string
+	output   string
 	genericclioptions.IOStreams
-	table *tablewriter.Table
-}
 
-func NewResultHelper(kuser common.KubeClientUser, name string, streams genericclioptions.IOStreams) common.ObjectHelper {
-	table := tablewriter.NewWriter(streams.Out)
-	table.SetHeader([]string{"Key", "Value"})
-	table.SetAutoWrapText(true)
-	table.SetReflowDuringAutoWrap(false)
-	table.SetAutoMergeCells(true)
-	table.SetRowLine(true)
+	resolver *common.RuleResolver
+}
 
+func NewResultHelper(kuser common.KubeClientUser, names []string, selector, output string, streams genericclioptions.IOStreams) common.ObjectHelper {
 	return &ResultHelper{
-		kuser: kuser,
-		name:  name,
-		kind:  "ComplianceCheckResult",
+		kuser:    kuser,
+		names:    names,
+		selector: selector,
+		output:   output,
+		kind:     "ComplianceCheckResult",
 		gvk: schema.GroupVersionResource{
 			Group:    common.CmpAPIGroup,
 			Version:  common.CmpResourceVersion,
 			Resource: "compliancecheckresults",
 		},
 		IOStreams: streams,
-		table:     table,
+		resolver:  common.NewRuleResolver(kuser),
 	}
 }
 
 func (h *ResultHelper) Handle() error {
-	res, err := h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), h.name, metav1.GetOptions{})
-	if err != nil {
-		return err
-	}
-
-	resultAnns := res.GetAnnotations()
-	if resultAnns == nil {
-		return fmt.Errorf("Result had no annotations, couldn't determine rule.")
-	}
-	ruleRef, ok := resultAnns[ruleAnnotationKey]
-	if !ok {
-		return fmt.Errorf("Malformed result. It doesn't contain a rule reference.")
-	}
-
-	rule, err := h.getRule(res, ruleRef)
+	results, err := h.getResults()
 	if err != nil {
 		return err
 	}
 
-	if err := h.stringToTable(rule, "title"); err != nil {
-		return err
-	}
-	if err := h.stringToTable(res, "status"); err != nil {
-		return err
-	}
-
-	if err := h.stringToTable(res, "severity"); err != nil {
-		return err
-	}
-	if err := h.stringToTable(rule, "description"); err != nil {
-		return err
-	}
-	if err := h.stringToTable(rule, "rationale"); err != nil {
-		return err
+	if len(results) == 0 {
+		return fmt.Errorf("No results matched the given selector '%s'", h.selector)
 	}
-	if err := h.stringToTableIfExists(res, "instructions"); err != nil {
-		return err
-	}
-
-	h.displayControls(rule)
 
-	if err := h.displayAvailableFixes(rule); err != nil {
-		return err
+	reports := make([]*ResultReport, 0, len(results))
+	for _, res := range results {
+		report, err := h.buildReport(res)
+		if err != nil {
+			return goerrors.Wrapf(err, "unable to get information for result %s", res.GetName())
+		}
+		reports = append(reports, report)
 	}
 
-	h.table.Append([]string{"Result Object Name", res.GetName()})
-	h.table.Append([]string{"Rule Object Name", rule.GetName()})
-
-	rem, err := h.getRemediation(res)
-	if err != nil {
-		return err
+	if h.output != common.OutputFormatTable {
+		// Keep the single result document when a single result was
+		// requested by name.
+		if len(h.names) == 1 && h.selector == "" {
+			return common.PrintStructured(h.Out, h.output, reports[0])
+		}
+		return common.PrintStructured(h.Out, h.output, &ResultReportList{
+			SchemaVersion: ResultReportSchemaVersion,
+			Items:         reports,
+		})
 	}
 
-	if rem != nil {
-		h.table.Append([]string{"Remediation Created", "Yes"})
-		h.table.Append([]string{"Remediation Name", rem.GetName()})
-		str, found, err := unstructured.NestedString(rem.Object, "status", "applicationState")
-		if err != nil {
-			return fmt.Errorf("Unable to get %s of %s/%s of type %s: %s", "applicationState", rem.GetNamespace(), rem.GetName(), rem.GetKind(), err)
-		}
-		if !found {
-			return fmt.Errorf("%s/%s of type %s: has no '%s'", rem.GetNamespace(), rem.GetName(), rem.GetKind(), "applicationState")
+	for idx, report := range reports {
+		if idx > 0 {
+			fmt.Fprintln(h.Out)
 		}
-
-		h.table.Append([]string{"Remediation Status", str})
-	} else {
-		h.table.Append([]string{"Remediation Created", "No"})
+		report.render(h.Out)
 	}
-	h.table.Render()
 	return nil
 }
 
-func (h *ResultHelper) stringToTableEx(obj *unstructured.Unstructured, mustExist bool, keys ...string) error {
-	str, found, err := unstructured.NestedString(obj.Object, keys...)
-	lastKey := keys[len(keys)-1]
-	if err != nil {
-		return fmt.Errorf("Unable to get %s of %s/%s of type %s: %s", lastKey, obj.GetNamespace(), obj.GetName(), obj.GetKind(), err)
-	}
-	if !found {
-		if !mustExist {
-			return nil
+// getResults fetches the results requested by name and the ones matching the
+// label selector. A result is only returned once even if it was requested
+// both ways.
+func (h *ResultHelper) getResults() ([]*unstructured.Unstructured, error) {
+	results := []*unstructured.Unstructured{}
+	seen := map[string]bool{}
+	for _, name := range h.names {
+		if seen[name] {
+			continue
+		}
+		res, err := h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
 		}
-		return fmt.Errorf("%s/%s of type %s: has no '%s'", obj.GetNamespace(), obj.GetName(), obj.GetKind(), lastKey)
+		seen[name] = true
+		results = append(results, res)
 	}
 
-	h.table.Append([]string{strings.Title(lastKey), str})
-	return nil
-}
-
-func (h *ResultHelper) stringToTable(obj *unstructured.Unstructured, keys ...string) error {
-	return h.stringToTableEx(obj, true, keys...)
-}
-
-func (h *ResultHelper) stringToTableIfExists(obj *unstructured.Unstructured, keys ...string) error {
-	return h.stringToTableEx(obj, false, keys...)
-}
-
-func (h *ResultHelper) displayControls(rule *unstructured.Unstructured) {
-	annotations := rule.GetAnnotations()
-	if annotations == nil {
-		// non-fatal... but no controls to display
-		return
+	if h.selector == "" {
+		return results, nil
 	}
 
-	for key, value := range annotations {
-		if strings.HasPrefix(key, controlAnnotationPrefix) {
-			benchmark := key[len(controlAnnotationPrefix):]
-			bmtext := fmt.Sprintf("%s Controls", benchmark)
-			commaSeparatedControls := strings.ReplaceAll(value, ";", ", ")
-			h.table.Append([]string{bmtext, commaSeparatedControls})
+	list, err := h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: h.selector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list resources of type %s: %s", h.kind, err)
+	}
+	for idx := range list.Items {
+		res := &list.Items[idx]
+		if seen[res.GetName()] {
+			continue
 		}
+		seen[res.GetName()] = true
+		results = append(results, res)
 	}
+	return results, nil
 }
 
-func (h *ResultHelper) displayAvailableFixes(rule *unstructured.Unstructured) error {
-	fixes, found, err := unstructured.NestedSlice(rule.Object, "availableFixes")
+// buildReport gathers all the information relevant to the given result
+func (h *ResultHelper) buildReport(res *unstructured.Unstructured) (*ResultReport, error) {
+	rule, err := h.resolver.GetRuleForResult(res)
 	if err != nil {
-		return fmt.Errorf("Unable to get %s of %s/%s of type %s: %s", "avaliableFixes", rule.GetNamespace(), rule.GetName(), rule.GetKind(), err)
+		return nil, err
 	}
-	if found && fixes != nil {
-		h.table.Append([]string{"Available Fix", "Yes"})
-		for _, fixObjRaw := range fixes {
-			fixObj, ok := fixObjRaw.(map[string]interface{})
-			if !ok {
-				return fmt.Errorf("Unable to parse %s of %s/%s of type %s: %s", "fixObject", rule.GetNamespace(), rule.GetName(), rule.GetKind(), err)
-			}
-			fix, found, err := unstructured.NestedMap(fixObj, "fixObject")
-			if err != nil {
-				return fmt.Errorf("Unable to get %s of %s/%s of type %s: %s", "fixObject", rule.GetNamespace(), rule.GetName(), rule.GetKind(), err)
-			}
-			if !found {
-				return fmt.Errorf("%s/%s of type %s: has no '%s'", rule.GetNamespace(), rule.GetName(), rule.GetKind(), "fixObject")
-			}
 
-			yamlSerializer := k8sserial.NewYAMLSerializer(k8sserial.DefaultMetaFactory, nil, nil)
-			objToPersist := &unstructured.Unstructured{Object: fix}
-			buf := bytes.Buffer{}
-			common.PersistObjectToYaml("", objToPersist, &buf, yamlSerializer)
-			h.table.Append([]string{"Fix Object", buf.String()})
-		}
-	} else {
-		h.table.Append([]string{"Available Fix", "No"})
+	report := &ResultReport{
+		SchemaVersion: ResultReportSchemaVersion,
+		ResultName:    res.GetName(),
+		RuleName:      rule.GetName(),
 	}
 
-	return nil
-}
-
-func (h *ResultHelper) getRule(res *unstructured.Unstructured, ruleRef string) (*unstructured.Unstructured, error) {
-	scan, err := getControllerOf(res, h.kuser)
-	if err != nil {
+	if report.Title, err = nestedString(rule, true, "title"); err != nil {
 		return nil, err
 	}
-	scanProfileXCCDFID, err := getProfileIDFromScan(scan)
-	if err != nil {
+	if report.Status, err = nestedString(res, true, "status"); err != nil {
 		return nil, err
 	}
-	scanDSFile, err := getDSFromScan(scan)
-	if err != nil {
+	if report.Severity, err = nestedString(res, true, "severity"); err != nil {
 		return nil, err
 	}
-	spi := scanProfileID{scanDSFile, scanProfileXCCDFID}
-	suite, err := getControllerOf(scan, h.kuser)
-	if err != nil {
-		return nil, goerrors.Wrapf(err, "cannot get a suite that owns scan %s", scan.GetName())
-	}
-	binding, err := getControllerOf(suite, h.kuser)
-	if err != nil {
-		return nil, goerrors.Wrapf(err, "cannot get a binding that owns suite %s", suite.GetName())
-	}
-	profs, err := h.getProfiles(binding)
-	if err != nil {
+	if report.Description, err = nestedString(rule, true, "description"); err != nil {
 		return nil, err
 	}
-	ph, err := h.findRelevantProfile(profs, binding, spi)
-	if err != nil {
+	if report.Rationale, err = nestedString(rule, true, "rationale"); err != nil {
 		return nil, err
 	}
-	return ph.FindRule(ruleRef)
-}
-
-func (h *ResultHelper) findRelevantProfile(profs []interface{}, binding *unstructured.Unstructured, spi scanProfileID) (profileHandler, error) {
-	for _, rawProf := range profs {
-		profRef, ok := rawProf.(map[string]interface{})
-		if !ok {
-			return nil, fmt.Errorf("Error parsing profiles from ScanSettingBinding '%s' that this result belongs to", binding.GetName())
-		}
-		gvr := getGVRFromProfileRef(profRef)
-		profname := profRef["name"].(string)
-		prof, err := h.kuser.DynamicClient().Resource(gvr).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), profname, metav1.GetOptions{})
-		if err != nil {
-			return nil, err
-		}
-		ph, err := getProfileHandler(prof, binding.GetName(), h.kuser)
-		if err != nil {
-			return nil, err
-		}
-		if ph.ProfileMatches(spi) {
-			return ph, nil
-		}
-	}
-
-	return nil, fmt.Errorf("Didn't find relevant profile")
-}
-
-// Get a profile from a scansettingBinding object
-func (h *ResultHelper) getProfiles(obj *unstructured.Unstructured) ([]interface{}, error) {
-	profs, found, err := unstructured.NestedSlice(obj.Object, "profiles")
-	if err != nil {
-		return nil, fmt.Errorf("Unable to get profiles of %s/%s of type %s: %s", obj.GetNamespace(), obj.GetName(), "ComplianceCheckResult", err)
-	}
-	if !found {
-		return nil, fmt.Errorf("%s/%s of type %s: has no 'profiles'", obj.GetNamespace(), obj.GetName(), "Profile")
-	}
-	return profs, nil
-}
-
-func (h *ResultHelper) getRemediation(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	remgvr := schema.GroupVersionResource{
-		Group:    common.CmpAPIGroup,
-		Version:  common.CmpResourceVersion,
-		Resource: "complianceremediations",
-	}
-	// First lets search for a remediation with the same name
-	rem, err := h.kuser.DynamicClient().Resource(remgvr).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
-	if err != nil && !errors.IsNotFound(err) {
+	if report.Instructions, err = nestedString(res, false, "instructions"); err != nil {
 		return nil, err
 	}
-	// TODO(jaosorior): Handle remediations with no matching name by iterating
-	// and checking owner references
-	return rem, nil
-}
-
-func getProfileHandler(obj *unstructured.Unstructured, parent string, k common.KubeClientUser) (profileHandler, error) {
-	rulegvr := schema.GroupVersionResource{
-		Group:    common.CmpAPIGroup,
-		Version:  common.CmpResourceVersion,
-		Resource: "rules",
-	}
 
-	switch obj.GetKind() {
-	case "Profile":
-		return &profileHandlerImpl{k, obj, rulegvr}, nil
-	case "TailoredProfile":
-		return &tailoredProfileHandlerImpl{k, obj, rulegvr, nil}, nil
-	}
-	return nil, fmt.Errorf("Got unkown type for profile '%s' in parent object '%s'", obj.GetName(), parent)
-}
-
-type profileHandler interface {
-	ProfileMatches(scanProfileID) bool
-	FindRule(string) (*unstructured.Unstructured, error)
-}
-
-type profileHandlerImpl struct {
-	kuser   common.KubeClientUser
-	obj     *unstructured.Unstructured
-	rulegvr schema.GroupVersionResource
-}
+	report.Controls = common.GetControlsFromRule(rule)
 
-func (ph *profileHandlerImpl) ProfileMatches(spi scanProfileID) bool {
-	objid, found, err := unstructured.NestedString(ph.obj.Object, "id")
-	if err != nil || !found {
-		return false
-	}
-	pb, err := getControllerOf(ph.obj, ph.kuser)
-	if err != nil {
-		// TODO(jaosorior): Should probably issue a warning
-		return false
-	}
-	contentFile, found, err := unstructured.NestedString(pb.Object, "spec", "contentFile")
-	if err != nil || !found {
-		// TODO(jaosorior): Should probably issue a warning
-		return false
+	if report.AvailableFixes, err = getAvailableFixes(rule); err != nil {
+		return nil, err
 	}
-	profspi := scanProfileID{contentFile, objid}
-	return spi.IsEqual(profspi)
-}
 
-func (ph *profileHandlerImpl) FindRule(ruleRef string) (*unstructured.Unstructured, error) {
-	rules, err := common.GetRulesFromProfile(ph.obj)
+	rem, err := h.getRemediation(res)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, rule := range rules {
-		// OPTIMIZATION: don't fetch the rule, the annotation should be a
-		// substring of the rule name
-		if !strings.Contains(rule, ruleRef) {
-			continue
-		}
-		ruleobj, err := ph.kuser.DynamicClient().Resource(ph.rulegvr).Namespace(ph.kuser.GetNamespace()).Get(context.TODO(), rule, metav1.GetOptions{})
+	if rem != nil {
+		str, found, err := unstructured.NestedString(rem.Object, "status", "applicationState")
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("Unable to get %s of %s/%s of type %s: %s", "applicationState", rem.GetNamespace(), rem.GetName(), rem.GetKind(), err)
 		}
-		ruleAnns := ruleobj.GetAnnotations()
-		// TODO(jaosorior): Should a warning be printed if a rule has no annotatins?
-		if ruleAnns == nil {
-			continue
+		if !found {
+			return nil, fmt.Errorf("%s/%s of type %s: has no '%s'", rem.GetNamespace(), rem.GetName(), rem.GetKind(), "applicationState")
 		}
-		if ruleRef == ruleAnns[ruleAnnotationKey] {
-			return ruleobj, nil
+		report.Remediation = &RemediationReport{
+			Name:             rem.GetName(),
+			ApplicationState: str,
 		}
 	}
-
-	return nil, fmt.Errorf("Didn't find relevant rule for extra information")
-}
-
-type tailoredProfileHandlerImpl struct {
-	kuser         common.KubeClientUser
-	obj           *unstructured.Unstructured
-	rulegvr       schema.GroupVersionResource
-	parentProfile *unstructured.Unstructured
+	return report, nil
 }
 
-func (tph *tailoredProfileHandlerImpl) ProfileMatches(spi scanProfileID) bool {
-	objid, found, err := unstructured.NestedString(tph.obj.Object, "status", "id")
-	if err != nil || !found {
-		return false
-	}
-	prof, err := tph.getParentProfile()
-	if err != nil {
-		// TODO(jaosorior): Should probably issue a warning
-		return false
-	}
-	pb, err := getControllerOf(prof, tph.kuser)
+// nestedString gets the string at the given path of the object. If mustExist
+// is set, a missing value is reported as an error.
+func nestedString(obj *unstructured.Unstructured, mustExist bool, keys ...string) (string, error) {
+	str, found, err := unstructured.NestedString(obj.Object, keys...)
+	lastKey := keys[len(keys)-1]
 	if err != nil {
-		// TODO(jaosorior): Should probably issue a warning
-		return false
+		return "", fmt.Errorf("Unable to get %s of %s/%s of type %s: %s", lastKey, obj.GetNamespace(), obj.GetName(), obj.GetKind(), err)
 	}
-	contentFile, found, err := unstructured.NestedString(pb.Object, "spec", "contentFile")
-	if err != nil || !found {
-		// TODO(jaosorior): Should probably issue a warning
-		return false
+	if !found && mustExist {
+		return "", fmt.Errorf("%s/%s of type %s: has no '%s'", obj.GetNamespace(), obj.GetName(), obj.GetKind(), lastKey)
 	}
-	profspi := scanProfileID{contentFile, objid}
-	return spi.IsEqual(profspi)
+	return str, nil
 }
 
-func (tph *tailoredProfileHandlerImpl) FindRule(ruleRef string) (*unstructured.Unstructured, error) {
-	prof, err := tph.getParentProfile()
+func getAvailableFixes(rule *unstructured.Unstructured) ([]map[string]interface{}, error) {
+	fixes, found, err := unstructured.NestedSlice(rule.Object, "availableFixes")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Unable to get %s of %s/%s of type %s: %s", "avaliableFixes", rule.GetNamespace(), rule.GetName(), rule.GetKind(), err)
+	}
+	if !found || fixes == nil {
+		return nil, nil
 	}
 
-	ph, err := getProfileHandler(prof, tph.obj.GetName(), tph.kuser)
-	if err != nil {
-		return nil, err
+	out := []map[string]interface{}{}
+	for _, fixObjRaw := range fixes {
+		fixObj, ok := fixObjRaw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Unable to parse %s of %s/%s of type %s", "fixObject", rule.GetNamespace(), rule.GetName(), rule.GetKind())
+		}
+		fix, found, err := unstructured.NestedMap(fixObj, "fixObject")
+		if err != nil {
+			return nil, fmt.Errorf("Unable to get %s of %s/%s of type %s: %s", "fixObject", rule.GetNamespace(), rule.GetName(), rule.GetKind(), err)
+		}
+		if !found {
+			return nil, fmt.Errorf("%s/%s of type %s: has no '%s'", rule.GetNamespace(), rule.GetName(), rule.GetKind(), "fixObject")
+		}
+		out = append(out, fix)
 	}
-	return ph.FindRule(ruleRef)
+	return out, nil
 }
 
-func (tph *tailoredProfileHandlerImpl) getParentProfile() (*unstructured.Unstructured, error) {
-	if tph.parentProfile != nil {
-		return tph.parentProfile, nil
-	}
-	profname, err := getProfileFromTailoredProfile(tph.obj)
-	if err != nil {
-		return nil, err
-	}
-	profgvr := schema.GroupVersionResource{
+func (h *ResultHelper) getRemediation(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	remgvr := schema.GroupVersionResource{
 		Group:    common.CmpAPIGroup,
 		Version:  common.CmpResourceVersion,
-		Resource: "profiles",
+		Resource: "complianceremediations",
 	}
-	prof, err := tph.kuser.DynamicClient().Resource(profgvr).Namespace(tph.kuser.GetNamespace()).Get(context.TODO(), profname, metav1.GetOptions{})
-	if err != nil {
+	// First lets search for a remediation with the same name
+	rem, err := h.kuser.DynamicClient().Resource(remgvr).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
 		return nil, err
 	}
-	tph.parentProfile = prof
-	return tph.parentProfile, nil
-}
-
-// Represents the necessary info to uniquely identify a profile
-type scanProfileID struct {
-	file string
-	id   string
-}
-
-func (spi scanProfileID) IsEqual(other scanProfileID) bool {
-	return spi.file == other.file && spi.id == other.id
-}
-
-// Helper Functions
-// ================
-
-func getControllerOf(res *unstructured.Unstructured, k common.KubeClientUser) (*unstructured.Unstructured, error) {
-	ctrl := metav1.GetControllerOf(res)
-	if ctrl == nil {
-		return nil, fmt.Errorf("the object had no owner")
-	}
-
-	gvr := getGVRFromAPIVersionAndKind(ctrl.APIVersion, ctrl.Kind)
-	return k.DynamicClient().Resource(gvr).Namespace(k.GetNamespace()).Get(context.TODO(), ctrl.Name, metav1.GetOptions{})
-}
-
-func getGVRFromProfileRef(profRef map[string]interface{}) schema.GroupVersionResource {
-	// NOTE: We wrongly named the apiVersion to be apiGroup
-	apiVersion := profRef["apiGroup"].(string)
-	kind := profRef["kind"].(string)
-	return getGVRFromAPIVersionAndKind(apiVersion, kind)
-}
-
-func getGVRFromAPIVersionAndKind(apiVersion, kind string) schema.GroupVersionResource {
-	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
-	return gvk.GroupVersion().WithResource(pluralizeKind(gvk.Kind))
-}
-
-func pluralizeKind(kind string) string {
-	ret := strings.ToLower(kind)
-	if strings.HasSuffix(ret, "s") {
-		return fmt.Sprintf("%ses", ret)
-	}
-	return fmt.Sprintf("%ss", ret)
-}
-
-func getProfileIDFromScan(obj *unstructured.Unstructured) (string, error) {
-	id, found, err := unstructured.NestedString(obj.Object, "spec", "profile")
-	if err != nil {
-		return "", fmt.Errorf("Unable to get profile id of %s/%s of type %s: %s", obj.GetNamespace(), obj.GetName(), "ComplianceScan", err)
-	}
-	if !found {
-		return "", fmt.Errorf("%s/%s of type %s: has no 'profile'", obj.GetNamespace(), obj.GetName(), "ComplianceScan")
-	}
-	return id, nil
-}
-
-func getDSFromScan(obj *unstructured.Unstructured) (string, error) {
-	fil, found, err := unstructured.NestedString(obj.Object, "spec", "content")
-	if err != nil {
-		return "", fmt.Errorf("Unable to get content file reference of %s/%s of type %s: %s", obj.GetNamespace(), obj.GetName(), "ComplianceScan", err)
-	}
-	if !found {
-		return "", fmt.Errorf("%s/%s of type %s: has no 'content'", obj.GetNamespace(), obj.GetName(), "ComplianceScan")
-	}
-	return fil, nil
-}
-
-func getProfileFromTailoredProfile(obj *unstructured.Unstructured) (string, error) {
-	prof, found, err := unstructured.NestedString(obj.Object, "spec", "extends")
-	if err != nil {
-		return "", fmt.Errorf("Unable to get profile name from %s/%s of type %s: %s", obj.GetNamespace(), obj.GetName(), obj.GetKind(), err)
-	}
-	if !found {
-		return "", fmt.Errorf("%s/%s of type %s: has no profile reference", obj.GetNamespace(), obj.GetName(), obj.GetKind())
-	}
-	return prof, nil
+	// TODO(jaosorior): Handle remediations with no matching name by iterating
+	// and checking owner references
+	return rem, nil
 }
@@ -0,0 +1,191 @@
+package waive
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+	"github.com/openshift/oc-compliance/internal/tailor"
+)
+
+// waiver holds the rules to waive in one of the profiles of the suite
+type waiver struct {
+	profile *unstructured.Unstructured
+	// results to waive, indexed by the rule name
+	results map[string][]string
+}
+
+type ComplianceSuiteHelper struct {
+	kuser      common.KubeClientUser
+	gvk        schema.GroupVersionResource
+	kind       string
+	name       string
+	statuses   map[string]bool
+	severities map[string]bool
+	selector   string
+	rationale  string
+	suffix     string
+	dryRun     bool
+	genericclioptions.IOStreams
+}
+
+func NewComplianceSuiteHelper(kuser common.KubeClientUser, name string, statuses, severities []string, selector, rationale, suffix string,
+	dryRun bool, streams genericclioptions.IOStreams) common.ObjectHelper {
+	return &ComplianceSuiteHelper{
+		kuser:      kuser,
+		name:       name,
+		kind:       "ComplianceSuite",
+		gvk:        common.GVR("compliancesuites"),
+		statuses:   toSet(statuses),
+		severities: toSet(severities),
+		selector:   selector,
+		rationale:  rationale,
+		suffix:     suffix,
+		dryRun:     dryRun,
+		IOStreams:  streams,
+	}
+}
+
+func toSet(items []string) map[string]bool {
+	set := map[string]bool{}
+	for _, item := range items {
+		set[strings.ToLower(item)] = true
+	}
+	return set
+}
+
+func (h *ComplianceSuiteHelper) Handle() error {
+	_, err := h.kuser.DynamicClient().Resource(h.gvk).Namespace(h.kuser.GetNamespace()).Get(context.TODO(), h.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Unable to get resource %s/%s of type %s: %s", h.kuser.GetNamespace(), h.name, h.kind, err)
+	}
+
+	selector := fmt.Sprintf("%s=%s", common.SuiteLabel, h.name)
+	if h.selector != "" {
+		selector = fmt.Sprintf("%s,%s", selector, h.selector)
+	}
+	list, err := h.kuser.DynamicClient().Resource(common.GVR("compliancecheckresults")).Namespace(h.kuser.GetNamespace()).List(
+		context.TODO(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("Unable to get results of suite %s/%s: %s", h.kuser.GetNamespace(), h.name, err)
+	}
+
+	waivers, err := h.getWaivers(list.Items)
+	if err != nil {
+		return err
+	}
+	if len(waivers) == 0 {
+		fmt.Fprintf(h.Out, "No results of suite %s need to be waived\n", h.name)
+		return nil
+	}
+
+	cache := common.NewObjectCache(h.kuser)
+	for _, w := range waivers {
+		tp, err := h.buildTailoredProfile(w, cache)
+		if err != nil {
+			return err
+		}
+		if err := tailor.CreateOrPrint(h.kuser, tp, h.dryRun, h.Out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getWaivers groups the results to waive by the profile that was scanned,
+// sorted by the profile's name
+func (h *ComplianceSuiteHelper) getWaivers(results []unstructured.Unstructured) ([]*waiver, error) {
+	resolver := common.NewRuleResolver(h.kuser)
+	waivers := map[string]*waiver{}
+	for idx := range results {
+		res := &results[idx]
+		status, _, _ := unstructured.NestedString(res.Object, "status")
+		severity, _, _ := unstructured.NestedString(res.Object, "severity")
+		if !h.statuses[strings.ToLower(status)] {
+			continue
+		}
+		if len(h.severities) > 0 && !h.severities[strings.ToLower(severity)] {
+			continue
+		}
+
+		ph, err := resolver.GetProfileForResult(res)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to get the profile of result %s: %s", res.GetName(), err)
+		}
+		rule, err := resolver.GetRuleForResult(res)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to get the rule of result %s: %s", res.GetName(), err)
+		}
+
+		prof := ph.GetObject()
+		key := fmt.Sprintf("%s/%s", prof.GetKind(), prof.GetName())
+		w, found := waivers[key]
+		if !found {
+			w = &waiver{profile: prof, results: map[string][]string{}}
+			waivers[key] = w
+		}
+		w.results[rule.GetName()] = append(w.results[rule.GetName()], fmt.Sprintf("%s (%s)", res.GetName(), status))
+	}
+
+	keys := []string{}
+	for key := range waivers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	out := []*waiver{}
+	for _, key := range keys {
+		out = append(out, waivers[key])
+	}
+	return out, nil
+}
+
+// buildTailoredProfile builds a TailoredProfile that checks what the scanned
+// profile does, except for the waived rules
+func (h *ComplianceSuiteHelper) buildTailoredProfile(w *waiver, cache *common.ObjectCache) (*unstructured.Unstructured, error) {
+	name := fmt.Sprintf("%s-%s", w.profile.GetName(), h.suffix)
+	b, err := tailor.NewTailoredProfileBuilderFrom(name, w.profile)
+	if err != nil {
+		return nil, err
+	}
+
+	title, _, _ := unstructured.NestedString(w.profile.Object, "title")
+	if b.Title != "" {
+		title = b.Title
+	}
+	b.Title = fmt.Sprintf("%s (with waivers)", title)
+	b.Description = fmt.Sprintf("%s/%s with the exceptions of the ComplianceSuite %s", w.profile.GetKind(), w.profile.GetName(), h.name)
+
+	rules := []string{}
+	for rule := range w.results {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	for _, rule := range rules {
+		if err := b.ExcludeRule(cache, rule, h.getRationale(w.results[rule])); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.Validate(cache); err != nil {
+		return nil, err
+	}
+	return b.Build()
+}
+
+// getRationale references the waived results, so the exceptions can be
+// traced back to them
+func (h *ComplianceSuiteHelper) getRationale(results []string) string {
+	sort.Strings(results)
+	rationale := fmt.Sprintf("Waives the ComplianceCheckResults of suite %s: %s", h.name, strings.Join(results, ", "))
+	if h.rationale != "" {
+		rationale = fmt.Sprintf("%s. %s", h.rationale, rationale)
+	}
+	return rationale
+}
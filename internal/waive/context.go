@@ -0,0 +1,83 @@
+package waive
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/openshift/oc-compliance/internal/common"
+)
+
+// statuses of the results that may be waived
+var waivableStatuses = []string{"FAIL", "MANUAL", "INCONSISTENT", "ERROR", "INFO"}
+
+// severities of the results that may be waived
+var validSeverities = []string{"high", "medium", "low", "unknown"}
+
+type WaiveContext struct {
+	common.CommandContext
+
+	Statuses   []string
+	Severities []string
+	Selector   string
+	Rationale  string
+	Suffix     string
+	DryRun     bool
+}
+
+func NewWaiveContext(streams genericclioptions.IOStreams) *WaiveContext {
+	return &WaiveContext{
+		CommandContext: common.CommandContext{
+			ConfigFlags: genericclioptions.NewConfigFlags(true),
+			IOStreams:   streams,
+		},
+	}
+}
+
+// Validate ensures that all required arguments and flag values are provided
+func (o *WaiveContext) Validate() error {
+	if err := validateValues("status", o.Statuses, waivableStatuses); err != nil {
+		return err
+	}
+	if err := validateValues("severity", o.Severities, validSeverities); err != nil {
+		return err
+	}
+	if o.Suffix == "" {
+		return fmt.Errorf("The suffix parameter can't be empty")
+	}
+
+	objref, err := common.ValidateObjectArgs(o.Args)
+	if err != nil {
+		return err
+	}
+
+	switch objref.Type {
+	case common.ComplianceSuite:
+		o.Helper = NewComplianceSuiteHelper(o.Kuser, objref.Name, o.Statuses, o.Severities, o.Selector, o.Rationale, o.Suffix,
+			o.DryRun, o.IOStreams)
+	default:
+		return fmt.Errorf("Invalid object type for this command")
+	}
+	return nil
+}
+
+func validateValues(param string, given, valid []string) error {
+	for _, value := range given {
+		found := false
+		for _, validValue := range valid {
+			if strings.EqualFold(value, validValue) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Invalid %s '%s'. Must be one of: %s", param, value, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
+
+func (o *WaiveContext) Run() error {
+	return o.Helper.Handle()
+}
@@ -1,6 +1,7 @@
 package e2e
 
 import (
+	"os/exec"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -26,11 +27,66 @@ var _ = Describe("bind", func() {
 		})
 	})
 
+	When("creating the ScanSetting", func() {
+		const ssbName = "bind-test-created-settings"
+		AfterEach(func() {
+			if !CurrentGinkgoTestDescription().Failed {
+				By("deleting the ScanSettingBinding and ScanSetting created by this test")
+				oc("delete", "scansettingbinding", ssbName)
+				oc("delete", "scansetting", ssbName)
+				time.Sleep(defaultSleep)
+			}
+		})
+
+		It("Successfully creates a ScanSetting and a ScanSettingBinding", func() {
+			oc("compliance", "bind", "--name", ssbName, "--create-settings", "--roles", "worker",
+				"--schedule", "0 3 * * *", "--raw-result-storage-rotation", "5", "profile/ocp4-cis-node")
+			out := oc("get", "scansetting", ssbName, "-o", "jsonpath={.schedule} {.roles} {.rawResultStorage.rotation}")
+			Expect(out).To(Equal(`0 3 * * * ["worker"] 5`))
+			time.Sleep(defaultSleep)
+			ocWaitFor("condition=ready", "scansettingbinding", ssbName)
+		})
+	})
+
 	When("using --dry-run", func() {
 		It("Successfully creates a ScanSettingBinding", func() {
 			ssb := oc("compliance", "bind", "--dry-run", "--name", "test", "profile/ocp4-cis")
 			Expect(ssb).Should(MatchRegexp(`.*\n\s+name: test\n.*`))
 			Expect(ssb).Should(MatchRegexp(`.*\n\s+kind: Profile\n\s+name: ocp4-cis\n.*`))
 		})
+
+		It("Displays the ScanSetting to create along with the ScanSettingBinding", func() {
+			out := oc("compliance", "bind", "--dry-run", "--name", "test", "--create-settings",
+				"--toleration", "node-role.kubernetes.io/master:NoSchedule", "profile/ocp4-cis")
+			Expect(out).Should(MatchRegexp(`(?s)kind: ScanSetting\n.*\n---\n.*kind: ScanSettingBinding\n`))
+			Expect(out).Should(MatchRegexp(`.*\n\s+- effect: NoSchedule\n\s+key: node-role.kubernetes.io/master\n\s+operator: Exists\n.*`))
+			Expect(out).Should(MatchRegexp(`.*\n\s+kind: ScanSetting\n\s+name: test\n.*`))
+		})
+
+		It("Reports all the objects that don't exist", func() {
+			cmd := exec.Command("oc", "compliance", "bind", "--dry-run", "--name", "test", "-S", "no-such-setting",
+				"profile/ocp4-cis", "profile/no-such-profile", "tailoredprofile/no-such-tailoredprofile")
+			out, err := cmd.CombinedOutput()
+			Expect(err).To(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("The ScanSetting 'no-such-setting' doesn't exist"))
+			Expect(string(out)).To(ContainSubstring("The Profile 'no-such-profile' doesn't exist"))
+			Expect(string(out)).To(ContainSubstring("The TailoredProfile 'no-such-tailoredprofile' doesn't exist"))
+		})
+
+		It("Reports the roles that don't select any node for node profiles", func() {
+			cmd := exec.Command("oc", "compliance", "bind", "--dry-run", "--name", "test", "--create-settings",
+				"--roles", "worker,no-such-role", "profile/ocp4-cis-node")
+			out, err := cmd.CombinedOutput()
+			Expect(err).To(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("The role 'no-such-role' of the ScanSetting 'test' doesn't select any node"))
+			Expect(string(out)).NotTo(ContainSubstring("The role 'worker'"))
+		})
+
+		It("Rejects invalid object types", func() {
+			cmd := exec.Command("oc", "compliance", "bind", "--dry-run", "--name", "test", "compliancesuite/ocp4-cis")
+			out, err := cmd.CombinedOutput()
+			Expect(err).To(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("Invalid type"))
+		})
 	})
 })
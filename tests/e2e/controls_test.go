@@ -1,6 +1,8 @@
 package e2e
 
 import (
+	"encoding/json"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -20,5 +22,64 @@ var _ = Describe("controls", func() {
 			out := verifyOCControls("ocp4-cis")
 			Expect(out).To(MatchRegexp(`.*CIS.*[0-9]+\.[0-9]+\.[0-9]+`))
 		})
+		It("CIS profile as CSV", func() {
+			out := oc("compliance", "controls", "profile", "ocp4-cis", "-b", "CIS-OCP", "-o", "csv")
+			Expect(out).To(HavePrefix("Framework,Control,Rule\n"))
+			Expect(out).To(MatchRegexp(`CIS-OCP,[0-9]+\.[0-9]+\.[0-9]+,ocp4-`))
+		})
+		It("CIS profile as JSON", func() {
+			out := oc("compliance", "controls", "profile", "ocp4-cis", "-o", "json")
+			mapping := map[string]map[string][]string{}
+			Expect(json.Unmarshal([]byte(out), &mapping)).To(Succeed())
+			Expect(mapping).To(HaveKey("CIS-OCP"))
+		})
+		It("CIS tailored profile", func() {
+			By("Asserting that the extended profile has the rule to disable")
+			Expect(verifyOCControls("ocp4-cis")).To(ContainSubstring("ocp4-api-server-anonymous-auth"))
+
+			ocApplyFromString(`---
+apiVersion: compliance.openshift.io/v1alpha1
+kind: TailoredProfile
+metadata:
+  name: controls-tailored-cis
+spec:
+  extends: ocp4-cis
+  title: CIS without anonymous auth check
+  description: CIS without anonymous auth check
+  disableRules:
+  - name: ocp4-api-server-anonymous-auth
+    rationale: Testing the controls command
+`)
+			defer oc("delete", "tailoredprofile", "controls-tailored-cis")
+
+			out := oc("compliance", "controls", "tailoredprofile", "controls-tailored-cis")
+			Expect(out).To(MatchRegexp(`.*CIS.*[0-9]+\.[0-9]+\.[0-9]+`))
+			Expect(out).ToNot(ContainSubstring("ocp4-api-server-anonymous-auth"))
+		})
+	})
+
+	Context("With a pre-existing profile being scanned", func() {
+		BeforeEach(func() {
+			withCISScan("controls-scan")
+		}, float64(scanDoneTimeout))
+
+		It("Shows the status of the controls of a ScanSettingBinding", func() {
+			out := oc("compliance", "controls", "scansettingbinding", "controls-scan")
+			Expect(out).To(MatchRegexp(`.*CIS.*[0-9]+\.[0-9]+\.[0-9]+.*(PASS|FAIL|PARTIAL|MANUAL)`))
+			Expect(out).To(MatchRegexp(`[0-9]+\.[0-9]%`))
+		})
+
+		It("Looks up the rules and results of a control", func() {
+			out := oc("compliance", "controls", "lookup", "--benchmark", "CIS-OCP", "--control", "1.2.1", "-o", "json")
+			Expect(out).To(ContainSubstring(`"name": "ocp4-api-server-anonymous-auth"`))
+			Expect(out).To(ContainSubstring(`"ocp4-cis"`))
+			Expect(out).To(ContainSubstring(`"name": "ocp4-cis-api-server-anonymous-auth"`))
+		})
+
+		It("Shows the status of the controls of a ComplianceSuite for a benchmark", func() {
+			out := oc("compliance", "controls", "compliancesuite", "controls-scan", "-b", "NIST-800-53")
+			Expect(out).To(MatchRegexp(`NIST-800-53.*[A-Z]+-[0-9]+`))
+			Expect(out).ToNot(ContainSubstring("CIS-OCP"))
+		})
 	})
 })
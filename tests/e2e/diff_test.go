@@ -0,0 +1,49 @@
+package e2e
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("diff", func() {
+	When("comparing profiles", func() {
+		It("Reports the rules that differ between two profiles", func() {
+			out := oc("compliance", "diff", "profile", "ocp4-cis", "profile", "ocp4-moderate")
+			Expect(out).To(MatchRegexp(`\+ rule\s+\|\s+ocp4-`))
+			Expect(out).To(MatchRegexp(`- rule\s+\|\s+ocp4-`))
+		})
+
+		It("Reports no differences for the same profile", func() {
+			out := oc("compliance", "diff", "profile/ocp4-cis", "profile/ocp4-cis")
+			Expect(out).To(ContainSubstring("No differences"))
+		})
+
+		It("Reports the rules disabled by a TailoredProfile as JSON", func() {
+			ocApplyFromString(`---
+apiVersion: compliance.openshift.io/v1alpha1
+kind: TailoredProfile
+metadata:
+  name: diff-tailored-cis
+spec:
+  extends: ocp4-cis
+  title: CIS without anonymous auth check
+  description: CIS without anonymous auth check
+  disableRules:
+  - name: ocp4-api-server-anonymous-auth
+    rationale: Testing the diff command
+`)
+			defer oc("delete", "tailoredprofile", "diff-tailored-cis")
+
+			out := oc("compliance", "diff", "profile", "ocp4-cis", "tailoredprofile", "diff-tailored-cis", "-o", "json")
+			diff := struct {
+				AddedRules   []string `json:"addedRules"`
+				RemovedRules []string `json:"removedRules"`
+			}{}
+			Expect(json.Unmarshal([]byte(out), &diff)).To(Succeed())
+			Expect(diff.AddedRules).To(BeEmpty())
+			Expect(diff.RemovedRules).To(Equal([]string{"ocp4-api-server-anonymous-auth"}))
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package e2e
+
+import (
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("diff-results", func() {
+	Context("With a pre-existing profile being scanned", func() {
+		BeforeEach(func() {
+			withCISScan("diff-results-scan")
+		}, float64(scanDoneTimeout))
+
+		It("Reports no changes between the same ComplianceSuite", func() {
+			out := oc("compliance", "diff-results", "compliancesuite", "diff-results-scan",
+				"compliancesuite", "diff-results-scan")
+			Expect(out).To(ContainSubstring("No checks changed"))
+		})
+
+		It("Reports no changes between raw results of the same run as JSON", func() {
+			before, err := ioutil.TempDir("", "diff-results-")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(before)
+			after, err := ioutil.TempDir("", "diff-results-")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(after)
+
+			oc("compliance", "fetch-raw", "compliancescan", "ocp4-cis", "-o", before)
+			oc("compliance", "fetch-raw", "compliancescan", "ocp4-cis", "-o", after)
+
+			out := oc("compliance", "diff-results", "--raw", before, after, "-o", "json")
+			Expect(out).To(ContainSubstring(`"newlyFailing": []`))
+			Expect(out).To(ContainSubstring(`"disappeared": []`))
+		})
+	})
+})
@@ -0,0 +1,102 @@
+package e2e
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// sarifLog holds the parts of a SARIF log the tests look at
+type sarifLog struct {
+	Version string `json:"version"`
+	Runs    []struct {
+		Tool struct {
+			Driver struct {
+				Rules []struct {
+					ID string `json:"id"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Taxonomies []struct {
+			Name string `json:"name"`
+		} `json:"taxonomies"`
+		Results []struct {
+			RuleID string `json:"ruleId"`
+			Kind   string `json:"kind"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+// junitTestSuites holds the parts of a JUnit report the tests look at
+type junitTestSuites struct {
+	Failures int `xml:"failures,attr"`
+	Suites   []struct {
+		Name  string `xml:"name,attr"`
+		Cases []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"testcase"`
+	} `xml:"testsuite"`
+}
+
+func parseSARIF(out []byte) sarifLog {
+	log := sarifLog{}
+	Expect(json.Unmarshal(out, &log)).To(Succeed())
+	Expect(log.Version).To(Equal("2.1.0"))
+	Expect(log.Runs).To(HaveLen(1))
+	Expect(log.Runs[0].Results).ToNot(BeEmpty())
+	return log
+}
+
+var _ = Describe("export", func() {
+	Context("With a pre-existing profile being scanned", func() {
+		var dir string
+
+		BeforeEach(func() {
+			withCISScan("export-scan")
+
+			var err error
+			dir, err = ioutil.TempDir("", "export-")
+			Expect(err).ToNot(HaveOccurred())
+		}, float64(scanDoneTimeout))
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("Exports the results of a ScanSettingBinding as SARIF", func() {
+			path := filepath.Join(dir, "results.sarif")
+			oc("compliance", "export", "scansettingbinding", "export-scan", "--format", "sarif", "--output-file", path)
+
+			out, err := ioutil.ReadFile(path)
+			Expect(err).ToNot(HaveOccurred())
+			log := parseSARIF(out)
+			Expect(log.Runs[0].Tool.Driver.Rules).ToNot(BeEmpty())
+			By("Mapping the control annotations to taxonomies")
+			Expect(log.Runs[0].Taxonomies).ToNot(BeEmpty())
+		})
+
+		It("Exports the results of a ComplianceSuite as JUnit", func() {
+			out := oc("compliance", "export", "compliancesuite", "export-scan", "--format", "junit",
+				"--manual-policy", "failure")
+
+			suites := junitTestSuites{}
+			Expect(xml.Unmarshal([]byte(out), &suites)).To(Succeed())
+			Expect(suites.Suites).To(HaveLen(1))
+			Expect(suites.Suites[0].Name).To(Equal("ocp4-cis"))
+			Expect(suites.Suites[0].Cases).ToNot(BeEmpty())
+			Expect(suites.Failures).To(BeNumerically(">", 0))
+		})
+
+		It("Exports fetched raw results as SARIF", func() {
+			oc("compliance", "fetch-raw", "compliancescan", "ocp4-cis", "-o", dir)
+
+			out := oc("compliance", "export", "--raw-dir", dir)
+			parseSARIF([]byte(out))
+		})
+	})
+})
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 
 	. "github.com/onsi/ginkgo"
@@ -48,7 +50,6 @@ var _ = Describe("fetch-raw", func() {
 		}
 
 		assertFetchRawWithHTMLWorks := func(objtype, objname, wdir string) {
-			skipIfError("which", "oscap")
 			By("Calling oc compliance fetch-raw with --html flag")
 			oc("compliance", "fetch-raw", "--html", objtype, objname, "-o", wdir)
 
@@ -76,6 +77,15 @@ var _ = Describe("fetch-raw", func() {
 			It("Fetches the HTML results to the appropriate directory", func() {
 				assertFetchRawWithHTMLWorks("compliancesuite", "fetch-raw-scan", dir)
 			})
+
+			It("Fetches the results of several scans at once", func() {
+				oc("compliance", "fetch-raw", "compliancesuite", "fetch-raw-scan", "--parallel", "2", "--continue-on-error", "-o", dir)
+
+				By("Getting items from scan")
+				dirraw := do("find", dir, "-name", "*.xml.bzip2")
+				dirs := strings.Split(dirraw, "\n")
+				assertFilesOutput(dirs)
+			})
 		})
 
 		When("using ComplianceScan", func() {
@@ -86,6 +96,55 @@ var _ = Describe("fetch-raw", func() {
 			It("Fetches the HTML results to the appropriate directory", func() {
 				assertFetchRawWithHTMLWorks("compliancescan", "ocp4-cis", dir)
 			})
+
+			It("Fetches results that can be inspected offline", func() {
+				assertFetchRawWorks("compliancescan", "ocp4-cis", dir)
+
+				By("Inspecting the fetched results")
+				out := oc("compliance", "inspect-raw", dir, "-o", "json")
+				Expect(out).To(MatchRegexp(`"ruleID": "xccdf_org.ssgproject.content_rule_[a-z0-9_]+"`))
+				Expect(out).To(MatchRegexp(`"result": "(pass|fail)"`))
+			})
+
+			It("Fetches all the result sets into a directory per index", func() {
+				oc("compliance", "fetch-raw", "compliancescan", "ocp4-cis", "--all-indexes", "-o", dir)
+
+				By("Getting items from the first index")
+				dirraw := do("find", filepath.Join(dir, "0"), "-name", "*.xml.bzip2")
+				dirs := strings.Split(dirraw, "\n")
+				assertFilesOutput(dirs)
+			})
+
+			It("Fetches the results with each extractor pod transport", func() {
+				for _, transport := range []string{"exec", "cp"} {
+					By("Fetching the results with the " + transport + " transport")
+					transportDir := filepath.Join(dir, transport)
+					do("mkdir", transportDir)
+					oc("compliance", "fetch-raw", "compliancescan", "ocp4-cis", "--transport", transport, "-o", transportDir)
+
+					dirraw := do("find", transportDir, "-name", "*.xml.bzip2")
+					dirs := strings.Split(dirraw, "\n")
+					assertFilesOutput(dirs)
+				}
+			})
+
+			It("Falls back to an extractor pod once the result server is gone", func() {
+				By("Failing with the resultserver transport, as the scan is done")
+				cmd := exec.Command("oc", "compliance", "fetch-raw", "compliancescan", "ocp4-cis", "--transport", "resultserver", "-o", dir)
+				out, err := cmd.CombinedOutput()
+				Expect(err).To(HaveOccurred())
+				Expect(string(out)).To(ContainSubstring("No running pod of scan ocp4-cis mounts the PersistentVolumeClaim"))
+
+				By("Fetching the results with the auto transport")
+				cmd = exec.Command("oc", "compliance", "fetch-raw", "compliancescan", "ocp4-cis", "-o", dir)
+				out, err = cmd.CombinedOutput()
+				Expect(err).ShouldNot(HaveOccurred(), string(out))
+				Expect(string(out)).To(ContainSubstring("falling back to an extractor pod"))
+
+				dirraw := do("find", dir, "-name", "*.xml.bzip2")
+				dirs := strings.Split(dirraw, "\n")
+				assertFilesOutput(dirs)
+			})
 		})
 
 	})
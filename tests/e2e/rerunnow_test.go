@@ -1,9 +1,11 @@
 package e2e
 
 import (
+	"os/exec"
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
-	"time"
 )
 
 const (
@@ -32,5 +34,41 @@ var _ = Describe("rerun-now", func() {
 			By("waiting for scan to be done")
 			ocWaitLongFor("condition=ready", "compliancesuite", "rerun-now-scan")
 		})
+
+		It("Doesn't re-run a scan that is already running", func() {
+			oc("compliance", "rerun-now", "compliancesuite", "rerun-now-scan")
+
+			By("re-running the scan again while it's pending")
+			out := oc("compliance", "rerun-now", "compliancesuite", "rerun-now-scan")
+			Expect(out).To(MatchRegexp(`(already running|already requested)`))
+			Expect(out).ToNot(ContainSubstring("Re-running scan"))
+
+			By("waiting for scan to be done")
+			ocWaitLongFor("condition=ready", "compliancesuite", "rerun-now-scan")
+		})
+
+		It("Re-runs the scan and waits for it to finish", func() {
+			cmd := exec.Command("oc", "compliance", "rerun-now", "compliancesuite", "rerun-now-scan", "--wait", "--timeout", "10m")
+			out, err := cmd.CombinedOutput()
+
+			By("checking that the scan went through its phases")
+			Expect(string(out)).To(ContainSubstring("Scan 'openshift-compliance/ocp4-e8' is RUNNING"))
+			Expect(string(out)).To(ContainSubstring("Scan 'openshift-compliance/ocp4-e8' finished with result"))
+			phase := oc("get", "compliancesuite", "rerun-now-scan", "-o", `jsonpath={.status.phase}`)
+			Expect(phase).Should(Equal("DONE"))
+
+			By("checking that the exit code reflects the result")
+			result := oc("get", "compliancesuite", "rerun-now-scan", "-o", `jsonpath={.status.result}`)
+			switch result {
+			case "COMPLIANT":
+				Expect(err).ToNot(HaveOccurred())
+			case "NON-COMPLIANT":
+				Expect(err).To(HaveOccurred())
+				Expect(cmd.ProcessState.ExitCode()).To(Equal(2))
+			default:
+				Expect(err).To(HaveOccurred())
+				Expect(cmd.ProcessState.ExitCode()).To(Equal(3))
+			}
+		})
 	})
 })
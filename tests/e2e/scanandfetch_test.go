@@ -0,0 +1,49 @@
+package e2e
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("scan-and-fetch", func() {
+	Context("With a pre-existing profile being scanned", func() {
+		var dir string
+
+		BeforeEach(func() {
+			withCISScan("scan-and-fetch-scan")
+			var tmpErr error
+			dir, tmpErr = ioutil.TempDir("", "oc-compliance-scan-and-fetch")
+			By(fmt.Sprintf("Created temporary directory for this test: %s", dir))
+			Expect(tmpErr).ShouldNot(HaveOccurred())
+		}, float64(scanDoneTimeout))
+
+		AfterEach(func() {
+			if !CurrentGinkgoTestDescription().Failed {
+				By(fmt.Sprintf("Removing temporary directory for this test: %s", dir))
+				os.RemoveAll(dir)
+			}
+		})
+
+		It("Re-runs the scans and fetches the new results", func() {
+			cmd := exec.Command("oc", "compliance", "scan-and-fetch", "scan-and-fetch-scan", "-o", dir, "--html", "--timeout", "10m")
+			out, err := cmd.CombinedOutput()
+
+			By("checking that the exit code reflects the NON-COMPLIANT result of the CIS profile")
+			Expect(err).To(HaveOccurred(), "Output: %s", out)
+			Expect(cmd.ProcessState.ExitCode()).To(Equal(2), "Output: %s", out)
+
+			By("checking that the results were fetched into a timestamped directory")
+			runDirs, globErr := filepath.Glob(filepath.Join(dir, "scan-and-fetch-scan-*"))
+			Expect(globErr).ToNot(HaveOccurred())
+			Expect(runDirs).To(HaveLen(1))
+			Expect(do("find", runDirs[0], "-name", "*.xml.bzip2")).ToNot(BeEmpty())
+			Expect(do("find", runDirs[0], "-name", "*.html")).ToNot(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package e2e
+
+import (
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("summary", func() {
+	Context("With a pre-existing profile being scanned", func() {
+		BeforeEach(func() {
+			withCISScan("summary-scan")
+		}, float64(scanDoneTimeout))
+
+		It("Summarizes the results of a ScanSettingBinding", func() {
+			out := oc("compliance", "summary", "scansettingbinding", "summary-scan")
+			Expect(out).To(MatchRegexp(`ocp4-cis\s+\|\s+[0-9]+`))
+			Expect(out).To(MatchRegexp(`high\s+\|\s+[0-9]+`))
+		})
+
+		It("Summarizes the results of a ComplianceSuite as JSON", func() {
+			out := oc("compliance", "summary", "compliancesuite", "summary-scan", "-o", "json")
+			Expect(out).To(ContainSubstring(`"name": "ocp4-cis"`))
+			Expect(out).To(MatchRegexp(`"total": [0-9]+`))
+		})
+
+		It("Fails when the fail threshold is exceeded", func() {
+			By("Asserting that the CIS profile has failing checks")
+			out := oc("get", "compliancecheckresults", "-l",
+				"compliance.openshift.io/check-status=FAIL,compliance.openshift.io/suite=summary-scan")
+			Expect(out).ToNot(ContainSubstring("No resources found"))
+
+			cmd := exec.Command("oc", "compliance", "summary", "compliancescan", "ocp4-cis", "--fail-threshold", "0")
+			_, err := cmd.CombinedOutput()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
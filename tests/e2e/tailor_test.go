@@ -0,0 +1,50 @@
+package e2e
+
+import (
+	"os/exec"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("tailor", func() {
+
+	When("creating a TailoredProfile", func() {
+		const tpName = "tailor-test-cis"
+		AfterEach(func() {
+			if !CurrentGinkgoTestDescription().Failed {
+				By("deleting TailoredProfiles created by this test")
+				oc("delete", "tailoredprofile", tpName)
+				time.Sleep(defaultSleep)
+			}
+		})
+
+		It("Successfully creates a TailoredProfile", func() {
+			oc("compliance", "tailor", tpName, "--extends", "profile/ocp4-cis",
+				"--disable-rule", "ocp4-api-server-anonymous-auth", "--rationale", "Testing the tailor command")
+			ocWaitFor("jsonpath={.status.state}=READY", "tailoredprofile", tpName)
+
+			out := oc("compliance", "diff", "profile/ocp4-cis", "tailoredprofile/"+tpName)
+			Expect(out).To(MatchRegexp(`- rule\s+\|\s+ocp4-api-server-anonymous-auth`))
+		})
+	})
+
+	When("using --dry-run", func() {
+		It("Displays the TailoredProfile", func() {
+			tp := oc("compliance", "tailor", "--dry-run", "test", "--extends", "ocp4-cis",
+				"--enable-rule", "ocp4-ocp-allowed-registries", "--rationale", "Testing the tailor command")
+			Expect(tp).Should(MatchRegexp(`.*\n\s+name: test\n.*`))
+			Expect(tp).Should(MatchRegexp(`.*\n\s+enableRules:\n\s+- name: ocp4-ocp-allowed-registries\n\s+rationale: Testing the tailor command\n.*`))
+			Expect(tp).Should(MatchRegexp(`.*\n\s+extends: ocp4-cis\n.*`))
+		})
+
+		It("Fails for rules that don't exist", func() {
+			cmd := exec.Command("oc", "compliance", "tailor", "--dry-run", "test", "--extends", "ocp4-cis",
+				"--disable-rule", "ocp4-this-rule-does-not-exist")
+			out, err := cmd.CombinedOutput()
+			Expect(err).To(HaveOccurred())
+			Expect(string(out)).To(ContainSubstring("The Rule 'ocp4-this-rule-does-not-exist' doesn't exist"))
+		})
+	})
+})
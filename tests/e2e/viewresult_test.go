@@ -29,4 +29,18 @@ var _ = Describe("viewresult", func() {
 		Expect(out).To(MatchRegexp(`Available Fix.*`))
 		Expect(out).To(MatchRegexp(`Remediation Created.*`))
 	})
+
+	It("gets relevant info for result as JSON", func() {
+		out := oc("compliance", "view-result", "-o", "json", targetResult)
+		Expect(out).To(ContainSubstring(`"schemaVersion": "v1"`))
+		Expect(out).To(ContainSubstring(`"resultName": "` + targetResult + `"`))
+		Expect(out).To(MatchRegexp(`"status": "[A-Z-]+"`))
+	})
+
+	It("gets relevant info for results matching a selector", func() {
+		out := oc("compliance", "view-result", "-o", "json",
+			"-l", "compliance.openshift.io/suite=viewresult-scan")
+		Expect(out).To(ContainSubstring(`"items": [`))
+		Expect(out).To(MatchRegexp(`"resultName": "[a-z0-9-]+"`))
+	})
 })
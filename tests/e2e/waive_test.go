@@ -0,0 +1,45 @@
+package e2e
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("waive", func() {
+	Context("With a pre-existing profile being scanned", func() {
+		BeforeEach(func() {
+			withCISScan("waive-scan")
+		}, float64(scanDoneTimeout))
+
+		It("Displays a TailoredProfile that waives the failing checks", func() {
+			tp := oc("compliance", "waive", "--dry-run", "compliancesuite", "waive-scan", "--status", "FAIL",
+				"--rationale", "Testing the waive command")
+			Expect(tp).Should(MatchRegexp(`.*\n\s+name: ocp4-cis-waived\n.*`))
+			Expect(tp).Should(MatchRegexp(`.*\n\s+extends: ocp4-cis\n.*`))
+			Expect(tp).Should(MatchRegexp(`.*\n\s+disableRules:\n\s+- name: ocp4-.*`))
+			Expect(tp).Should(ContainSubstring("Testing the waive command. Waives the ComplianceCheckResults of suite waive-scan: ocp4-cis-"))
+		})
+
+		When("creating the TailoredProfile", func() {
+			const tpName = "ocp4-cis-waived"
+			AfterEach(func() {
+				if !CurrentGinkgoTestDescription().Failed {
+					By("deleting TailoredProfiles created by this test")
+					oc("delete", "tailoredprofile", tpName)
+					time.Sleep(defaultSleep)
+				}
+			})
+
+			It("Successfully creates a TailoredProfile without the failing rules", func() {
+				oc("compliance", "waive", "compliancesuite", "waive-scan")
+				ocWaitFor("jsonpath={.status.state}=READY", "tailoredprofile", tpName)
+
+				out := oc("compliance", "diff", "profile/ocp4-cis", "tailoredprofile/"+tpName)
+				Expect(out).To(MatchRegexp(`- rule\s+\|\s+ocp4-`))
+				Expect(out).ToNot(MatchRegexp(`\+ rule`))
+			})
+		})
+	})
+})